@@ -0,0 +1,46 @@
+package cron
+
+import (
+	"time"
+)
+
+/*
+WithNotBefore will keep the schedule from activating before t, NextFrom returns
+t itself when it is an activation
+*/
+func WithNotBefore(t time.Time) Option {
+	return func(c *config) {
+		c.notBefore = t
+	}
+}
+
+/*
+WithNotAfter will keep the schedule from activating after t. Once the schedule is
+exhausted NextFrom returns the zero time, as it does for a schedule that never activates
+*/
+func WithNotAfter(t time.Time) Option {
+	return func(c *config) {
+		c.notAfter = t
+	}
+}
+
+/*
+NotBefore returns the first time the schedule may activate, or the zero time
+when it is not bounded
+*/
+func (c *Cron) NotBefore() time.Time {
+	return c.notBefore
+}
+
+/*
+NotAfter returns the last time the schedule may activate, or the zero time
+when it is not bounded
+*/
+func (c *Cron) NotAfter() time.Time {
+	return c.notAfter
+}
+
+// inBounds reports whether t is within the NotBefore and NotAfter bounds of the schedule
+func (c *Cron) inBounds(t time.Time) bool {
+	return (c.notBefore.IsZero() || !t.Before(c.notBefore)) && (c.notAfter.IsZero() || !t.After(c.notAfter))
+}
@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWithNotBefore_NotAfter(t *testing.T) {
+	notBefore := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	cron := MustParse("0 12 * * 1", WithNotBefore(notBefore), WithNotAfter(notAfter))
+	assert.Equal(t, notBefore, cron.NotBefore())
+	assert.Equal(t, notAfter, cron.NotAfter())
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{name: "next before the start", got: cron.NextFrom(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), want: time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)},
+		{name: "next within", got: cron.NextFrom(time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)), want: time.Date(2024, 3, 25, 12, 0, 0, 0, time.UTC)},
+		{name: "next exhausted", got: cron.NextFrom(time.Date(2024, 3, 25, 12, 0, 0, 0, time.UTC)), want: time.Time{}},
+		{name: "next after the end", got: cron.NextFrom(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)), want: time.Time{}},
+		{name: "prev after the end", got: cron.PrevBefore(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)), want: time.Date(2024, 3, 25, 12, 0, 0, 0, time.UTC)},
+		{name: "prev within", got: cron.PrevBefore(time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)), want: time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC)},
+		{name: "prev before the start", got: cron.PrevBefore(time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)), want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(tt.got), "got %v, want %v", tt.got, tt.want)
+		})
+	}
+}
+
+// TestWithNotBefore_NotAfter_Inclusive checks that activations on the bounds are kept
+func TestWithNotBefore_NotAfter_Inclusive(t *testing.T) {
+	first := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 3, 25, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(first.Add(-7 * 24 * time.Hour))
+	cron := MustParse("0 12 * * 1", WithNotBefore(first), WithNotAfter(last), WithClock(clock))
+
+	assert.False(t, cron.Now())
+	assert.True(t, first.Equal(cron.Next()))
+	clock.Set(first)
+	assert.True(t, cron.Now())
+	clock.Set(last)
+	assert.True(t, cron.Now())
+	assert.True(t, cron.Next().IsZero())
+	clock.Set(last.Add(7 * 24 * time.Hour))
+	assert.False(t, cron.Now())
+	assert.True(t, last.Equal(cron.Prev()))
+}
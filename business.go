@@ -0,0 +1,113 @@
+package cron
+
+import (
+	"time"
+)
+
+// maxRoll bounds how many days a day is moved to reach a business day, so that
+// a weekend and holidays that cover every day do not search forever
+const maxRoll = 366
+
+/*
+Roll decides what happens to a day selected by the day fields that is not a
+business day, see WithBusinessDays
+*/
+type Roll uint8
+
+const (
+	// RollSkip leaves the day out, the schedule does not activate until the next day it selects
+	RollSkip Roll = iota
+	// RollForward moves the day to the next business day
+	RollForward
+	// RollBackward moves the day to the previous business day
+	RollBackward
+)
+
+/*
+WithBusinessDays will keep the schedule to business days, which are the days
+that are not on the weekend (Saturday and Sunday unless WithWeekend is given)
+and not excluded by any of the holiday calendars. A day the day fields select
+that is not a business day is skipped or moved according to roll, i.e. "0 0 1 * *"
+with RollForward activates on the 1st of the month or the business day after it,
+and "0 0 L * *" with RollBackward on the last business day of the month. A day
+moved into another month or year still activates
+*/
+func WithBusinessDays(roll Roll, holidays ...Calendar) Option {
+	return func(c *config) {
+		c.businessDays = true
+		c.roll = roll
+		c.holidays = append(c.holidays, holidays...)
+	}
+}
+
+/*
+WithWeekend will set the days of the week that are not business days for
+WithBusinessDays, by default Saturday and Sunday
+*/
+func WithWeekend(days ...time.Weekday) Option {
+	return func(c *config) {
+		c.weekend = set[uint8]{}
+		for _, day := range days {
+			c.weekend.add(uint8(day))
+		}
+		c.customWeekend = true
+	}
+}
+
+// isBusinessDay reports whether the day of t is neither on the weekend nor a holiday
+func (c *Cron) isBusinessDay(t time.Time) bool {
+	weekend := c.weekend
+	if !c.customWeekend {
+		weekend = newSet[uint8](uint8(time.Saturday), uint8(time.Sunday))
+	}
+	if weekend.contains(uint8(t.Weekday())) {
+		return false
+	}
+	for _, holiday := range c.holidays {
+		if holiday.Excludes(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// isRolledDay reports whether the day of t is a business day that the fields select,
+// or that a day they select which is not a business day rolls to. The month and
+// year fields are part of the check, as they apply to the day before it is rolled.
+func (c *Cron) isRolledDay(t time.Time) bool {
+	if !c.isBusinessDay(t) {
+		return false
+	}
+	if c.isSelectedDay(t) {
+		return true
+	}
+
+	// A day rolled forward comes from the days before t that are not business
+	// days, a day rolled backward from those after it
+	step := 0
+	switch c.roll {
+	case RollForward:
+		step = -1
+	case RollBackward:
+		step = 1
+	default:
+		return false
+	}
+	year, month, day := t.Date()
+	for i := 1; i <= maxRoll; i++ {
+		// Noon is on the same day whatever daylight saving does
+		other := time.Date(year, month, day+i*step, 12, 0, 0, 0, t.Location())
+		if c.isBusinessDay(other) {
+			return false
+		}
+		if c.isSelectedDay(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSelectedDay reports whether the year, month and day fields select the day of t
+func (c *Cron) isSelectedDay(t time.Time) bool {
+	return c.year.contains(t.Year()) && c.month.contains(uint8(t.Month())) && c.fieldsMatchDay(t)
+}
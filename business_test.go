@@ -0,0 +1,124 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWithBusinessDays(t *testing.T) {
+	// Monday the 1st of January 2024, and Good Friday and Easter Monday
+	newYear := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	easter := Dates(time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name     string
+		schedule string
+		opts     []Option
+		from     time.Time
+		want     []time.Time
+	}{
+		{
+			name:     "first of the month or the next business day",
+			schedule: "0 6 1 * *",
+			opts:     []Option{WithBusinessDays(RollForward, Dates(newYear), easter)},
+			from:     time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 1, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 2, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 6, 3, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "last business day of the month",
+			schedule: "0 18 L * *",
+			opts:     []Option{WithBusinessDays(RollBackward, easter)},
+			from:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 1, 31, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 29, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 28, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 30, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 5, 31, 18, 0, 0, 0, time.UTC),
+				time.Date(2024, 6, 28, 18, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "skip",
+			schedule: "0 6 1 * *",
+			opts:     []Option{WithBusinessDays(RollSkip, Dates(newYear))},
+			from:     time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 2, 1, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 1, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "rolled into the next year",
+			schedule: "0 6 31 12 *",
+			opts:     []Option{WithBusinessDays(RollForward)},
+			from:     time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 1, 1, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 12, 31, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "days rolled onto the same day activate once",
+			schedule: "0 6 * * 0,1,6",
+			opts:     []Option{WithBusinessDays(RollForward)},
+			from:     time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 1, 8, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 15, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "friday and saturday weekend",
+			schedule: "0 6 1 * *",
+			opts:     []Option{WithBusinessDays(RollForward), WithWeekend(time.Friday, time.Saturday)},
+			from:     time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 3, 3, 6, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 1, 6, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron := MustParse(tt.schedule, tt.opts...)
+			from := tt.from
+			for _, want := range tt.want {
+				next := cron.NextFrom(from)
+				if !assert.True(t, want.Equal(next), "got %v, want %v", next, want) {
+					return
+				}
+				assert.True(t, next.Equal(cron.PrevBefore(next.Add(time.Minute))))
+				from = next
+			}
+		})
+	}
+}
+
+func TestWithBusinessDays_Now(t *testing.T) {
+	// Saturday the 1st of June 2024
+	clock := NewFakeClock(time.Date(2024, 6, 1, 6, 0, 0, 0, time.UTC))
+	cron := MustParse("0 6 1 * *", WithBusinessDays(RollForward), WithClock(clock))
+	assert.False(t, cron.Now())
+	assert.True(t, time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC).Equal(cron.Prev()))
+
+	clock.Set(time.Date(2024, 6, 3, 6, 0, 0, 0, time.UTC))
+	assert.True(t, cron.Now())
+}
+
+func TestWithBusinessDays_NoBusinessDays(t *testing.T) {
+	cron := MustParse("0 6 1 * *", WithBusinessDays(RollForward), WithWeekend(time.Sunday, time.Monday, time.Tuesday,
+		time.Wednesday, time.Thursday, time.Friday, time.Saturday))
+	assert.True(t, cron.NextFrom(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+
+	_, err := cron.RRule()
+	assert.ErrorIs(t, err, UnrepresentableSchedule)
+}
@@ -0,0 +1,191 @@
+package cron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+/*
+Calendar excludes days from a schedule, such as bank holidays or a change freeze.
+Excludes is given a time on the day in the location of the schedule, and reports
+whether the schedule must not activate at all that day
+*/
+type Calendar interface {
+	Excludes(t time.Time) bool
+}
+
+/*
+CalendarFunc adapts a function to a Calendar
+*/
+type CalendarFunc func(t time.Time) bool
+
+func (f CalendarFunc) Excludes(t time.Time) bool {
+	return f(t)
+}
+
+/*
+WithCalendar will exclude the days of the calendar from the schedule, so that
+NextFrom, PrevBefore and Now skip them. It may be given more than once, a day
+excluded by any of the calendars is skipped
+*/
+func WithCalendar(calendar Calendar) Option {
+	return func(c *config) {
+		c.calendars = append(c.calendars, calendar)
+	}
+}
+
+// date is a day of the calendar, whatever the location
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	year, month, day := t.Date()
+	return date{year: year, month: month, day: day}
+}
+
+// dates is a Calendar of single dates, and of dates that recur every year
+type dates struct {
+	once   map[date]bool
+	annual map[date]bool
+}
+
+func (d *dates) Excludes(t time.Time) bool {
+	day := dateOf(t)
+	if d.once[day] {
+		return true
+	}
+	day.year = 0
+	return d.annual[day]
+}
+
+/*
+Dates returns a Calendar that excludes the dates of the times, such as the bank
+holidays of a year. The year, month and day of each time are used as they are,
+whatever its location
+*/
+func Dates(times ...time.Time) Calendar {
+	d := &dates{once: map[date]bool{}}
+	for _, t := range times {
+		d.once[dateOf(t)] = true
+	}
+	return d
+}
+
+/*
+Annual returns a Calendar that excludes the month and day of each of the times
+every year, such as the 25th of December, as the AnnualCalendar of Quartz does
+*/
+func Annual(times ...time.Time) Calendar {
+	d := &dates{annual: map[date]bool{}}
+	for _, t := range times {
+		d.annual[date{month: t.Month(), day: t.Day()}] = true
+	}
+	return d
+}
+
+/*
+Blackout returns a Calendar that excludes every day from the date of first
+to the date of last, including both
+*/
+func Blackout(first, last time.Time) Calendar {
+	from, to := dateOf(first), dateOf(last)
+	return CalendarFunc(func(t time.Time) bool {
+		day := dateOf(t)
+		return !day.before(from) && !to.before(day)
+	})
+}
+
+// before reports whether d is an earlier date than other
+func (d date) before(other date) bool {
+	if d.year != other.year {
+		return d.year < other.year
+	}
+	if d.month != other.month {
+		return d.month < other.month
+	}
+	return d.day < other.day
+}
+
+/*
+ReadDates reads a Calendar from a list of dates, one to a line. A line is either a
+date (2024-12-25), a month and day excluded every year (12-25), or the first and
+last date of a blackout (2024-12-23..2025-01-02), and may be followed by a name.
+Blank lines and lines starting with # are skipped. The line number of the first
+line that cannot be read is part of the error
+*/
+func ReadDates(r io.Reader) (Calendar, error) {
+	d := &dates{once: map[date]bool{}, annual: map[date]bool{}}
+	var blackouts []Calendar
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		// Anything after the date is its name
+		value := strings.Fields(text)[0]
+
+		switch first, last, isRange := strings.Cut(value, ".."); {
+		case isRange:
+			from, err := time.Parse(time.DateOnly, first)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			to, err := time.Parse(time.DateOnly, last)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			if to.Before(from) {
+				return nil, fmt.Errorf("line %d: %s is before %s", line, last, first)
+			}
+			blackouts = append(blackouts, Blackout(from, to))
+		case len(value) == len("01-02"):
+			t, err := time.Parse("01-02", value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			d.annual[date{month: t.Month(), day: t.Day()}] = true
+		default:
+			t, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			d.once[dateOf(t)] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(blackouts) == 0 {
+		return d, nil
+	}
+	return CalendarFunc(func(t time.Time) bool {
+		if d.Excludes(t) {
+			return true
+		}
+		for _, blackout := range blackouts {
+			if blackout.Excludes(t) {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// excluded reports whether any of the calendars of the schedule excludes the day of t
+func (c *Cron) excluded(t time.Time) bool {
+	for _, calendar := range c.calendars {
+		if calendar.Excludes(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package cron
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithCalendar(t *testing.T) {
+	// Monday the 25th and Tuesday the 26th of December 2023
+	christmas := time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)
+	boxingDay := time.Date(2023, 12, 26, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		schedule  string
+		calendars []Calendar
+		from      time.Time
+		want      time.Time
+	}{
+		{
+			name:      "dates",
+			schedule:  "0 6 * * 1-5",
+			calendars: []Calendar{Dates(christmas, boxingDay)},
+			from:      time.Date(2023, 12, 22, 12, 0, 0, 0, time.UTC),
+			want:      time.Date(2023, 12, 27, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "annual",
+			schedule:  "0 6 25 12 *",
+			calendars: []Calendar{Annual(christmas)},
+			from:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Time{},
+		},
+		{
+			name:      "blackout",
+			schedule:  "0 6 * * *",
+			calendars: []Calendar{Blackout(time.Date(2023, 12, 23, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))},
+			from:      time.Date(2023, 12, 22, 12, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 1, 3, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "more than one",
+			schedule: "0 6 * * *",
+			calendars: []Calendar{
+				Dates(christmas),
+				CalendarFunc(func(t time.Time) bool { return t.Day() == 26 }),
+			},
+			from: time.Date(2023, 12, 24, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2023, 12, 27, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "either day field",
+			schedule:  "0 6 25 * 2",
+			calendars: []Calendar{Dates(christmas, boxingDay)},
+			from:      time.Date(2023, 12, 22, 12, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithDayMatch(DayMatchOr)}
+			for _, calendar := range tt.calendars {
+				opts = append(opts, WithCalendar(calendar))
+			}
+			cron := MustParse(tt.schedule, opts...)
+			next := cron.NextFrom(tt.from)
+			assert.True(t, tt.want.Equal(next), "got %v, want %v", next, tt.want)
+			if !next.IsZero() {
+				assert.True(t, cron.PrevBefore(next.Add(time.Minute)).Equal(next))
+			}
+		})
+	}
+}
+
+// TestWithCalendar_Location checks that the days are those of the location of the schedule
+func TestWithCalendar_Location(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if !assert.NoError(t, err) {
+		return
+	}
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC))
+	cron := MustParse("30 9 * * *", WithLocation(tokyo), WithClock(clock), WithCalendar(Dates(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))))
+	assert.False(t, cron.Now())
+	assert.True(t, time.Date(2024, 1, 2, 0, 30, 0, 0, time.UTC).Equal(cron.Next()))
+	assert.True(t, time.Date(2023, 12, 31, 0, 30, 0, 0, time.UTC).Equal(cron.Prev()))
+
+	clock.Set(time.Date(2024, 1, 2, 0, 30, 0, 0, time.UTC))
+	assert.True(t, cron.Now())
+}
+
+func TestWithCalendar_Unrepresentable(t *testing.T) {
+	cron := MustParse("0 9 * * 1-5", WithCalendar(Annual(time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC))))
+	_, err := cron.RRule()
+	assert.ErrorIs(t, err, UnrepresentableSchedule)
+	_, err = cron.EventBridge()
+	assert.ErrorIs(t, err, UnrepresentableSchedule)
+	_, err = cron.OnCalendar()
+	assert.ErrorIs(t, err, UnrepresentableSchedule)
+}
+
+func TestReadDates(t *testing.T) {
+	calendar, err := ReadDates(strings.NewReader(`# Bank holidays
+2024-01-01 New Year's Day
+2024-05-27   Spring bank holiday
+
+12-25 Christmas Day
+12-26
+2024-08-01..2024-08-14 Summer freeze
+`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	tests := []struct {
+		date time.Time
+		want bool
+	}{
+		{date: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2024, 5, 27, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2031, 12, 25, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2024, 12, 26, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2024, 12, 27, 9, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2024, 7, 31, 9, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2024, 8, 1, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2024, 8, 14, 23, 59, 0, 0, time.UTC), want: true},
+		{date: time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date.Format(time.DateOnly), func(t *testing.T) {
+			assert.Equal(t, tt.want, calendar.Excludes(tt.date))
+		})
+	}
+}
+
+func TestReadDates_Error(t *testing.T) {
+	tests := []struct {
+		name  string
+		dates string
+		want  string
+	}{
+		{name: "not a date", dates: "2024-01-01\nnext tuesday\n", want: `line 2: parsing time "next"`},
+		{name: "no such day", dates: "\n\n2024-02-30\n", want: "line 3: parsing time \"2024-02-30\": day out of range"},
+		{name: "no such annual day", dates: "13-01", want: "line 1: parsing time \"13-01\": month out of range"},
+		{name: "reversed blackout", dates: "2024-08-14..2024-08-01", want: "line 1: 2024-08-01 is before 2024-08-14"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadDates(strings.NewReader(tt.dates))
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.want)
+			}
+		})
+	}
+
+	failed := errors.New("read failed")
+	_, err := ReadDates(failingReader{failed})
+	assert.ErrorIs(t, err, failed)
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
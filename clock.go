@@ -0,0 +1,107 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+/*
+Clock provides the current time to a Cron, supplying one allows
+callers to control and simulate time
+*/
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+/*
+SystemClock is the Clock backed by the system time, it is used
+by any Cron that has not been given a Clock
+*/
+var SystemClock Clock = systemClock{}
+
+/*
+FakeClock is a Clock that only moves when it is told to,
+it is safe for concurrent use
+*/
+type FakeClock struct {
+	mu      sync.RWMutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+// fakeWaiter is a channel given by After, and the time it is sent at
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+/*
+NewFakeClock returns a FakeClock stopped at now
+*/
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now,
+	}
+}
+
+/*
+Now returns the time the FakeClock is currently stopped at
+*/
+func (f *FakeClock) Now() time.Time {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.now
+}
+
+/*
+Set moves the FakeClock to now
+*/
+func (f *FakeClock) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+	f.wake()
+}
+
+/*
+Advance moves the FakeClock forward by d, or backwards if d is negative
+*/
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.wake()
+}
+
+/*
+After returns a channel that the time is sent on once the FakeClock has been moved
+on by d, as time.After does for the system time, so that code waiting on the clock
+follows the simulated time
+*/
+func (f *FakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	f.waiters = append(f.waiters, fakeWaiter{at: f.now.Add(d), ch: ch})
+	f.wake()
+	return ch
+}
+
+// wake sends the time to the waiters that are due, f.mu must be held
+func (f *FakeClock) wake() {
+	waiting := f.waiters[:0]
+	for _, waiter := range f.waiters {
+		if waiter.at.After(f.now) {
+			waiting = append(waiting, waiter)
+			continue
+		}
+		waiter.ch <- f.now
+	}
+	f.waiters = waiting
+}
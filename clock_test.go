@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	assert.True(t, start.Equal(clock.Now()))
+
+	clock.Advance(90 * time.Second)
+	assert.True(t, start.Add(90*time.Second).Equal(clock.Now()))
+
+	clock.Advance(-30 * time.Second)
+	assert.True(t, start.Add(time.Minute).Equal(clock.Now()))
+
+	clock.Set(start)
+	assert.True(t, start.Equal(clock.Now()))
+}
+
+func TestCron_WithClock(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC))
+	cron, err := Parse("*/5 * * * *", WithClock(clock))
+	assert.NoError(t, err)
+
+	assert.False(t, cron.Now())
+	assert.True(t, time.Date(2023, 6, 17, 18, 25, 0, 0, time.UTC).Equal(cron.Next()))
+	assert.True(t, time.Date(2023, 6, 17, 18, 20, 0, 0, time.UTC).Equal(cron.Prev()))
+
+	clock.Advance(2 * time.Minute)
+	assert.True(t, cron.Now())
+	assert.True(t, time.Date(2023, 6, 17, 18, 30, 0, 0, time.UTC).Equal(cron.Next()))
+	assert.True(t, time.Date(2023, 6, 17, 18, 20, 0, 0, time.UTC).Equal(cron.Prev()))
+
+	clock.Set(time.Date(2024, 1, 1, 0, 4, 59, 0, time.UTC))
+	assert.False(t, cron.Now())
+	assert.True(t, time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC).Equal(cron.Next()))
+}
+
+func TestFakeClock_After(t *testing.T) {
+	start := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	minute := clock.After(time.Minute)
+	now := clock.After(0)
+	assert.Equal(t, start, <-now)
+
+	clock.Advance(59 * time.Second)
+	select {
+	case <-minute:
+		t.Fatal("sent before the minute passed")
+	default:
+	}
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, start.Add(61*time.Second), <-minute)
+}
@@ -1,47 +1,145 @@
 package cron
 
 import (
+	"strings"
 	"time"
 )
 
+// searchYears bounds how far NextFrom and PrevBefore look for an activation,
+// schedules such as "0 0 30 2 *" would otherwise be searched forever. It is
+// wide enough for the rarest real schedules, i.e. the 29th of February on a
+// given weekday.
+const searchYears = 50
+
 /*
-Cron represents the cron schedule
+Cron represents the cron schedule. A Cron is not modified once
+it has been parsed, so it is safe for concurrent use
 */
 type Cron struct {
+	second  set[uint8]
 	minute  set[uint8]
 	hour    set[uint8]
 	day     set[uint8]
 	month   set[uint8]
 	weekday set[uint8]
-	utc     bool
+	year    yearSet
+
+	// The rules of the day fields that depend on the month, see dayRulesMatch
+	lastDays        set[uint8]
+	lastWeekday     bool
+	nearestWeekdays set[uint8]
+	lastWeekdays    set[uint8]
+	nthWeekdays     set[uint8]
+
+	// dayStar and weekdayStar record a day field written as * or ?, which
+	// decides how the day fields are combined under DayMatchOr
+	dayStar     bool
+	weekdayStar bool
+
+	seconds  bool
+	years    bool
+	dayMatch DayMatch
+	loc      *time.Location
+	clock    Clock
+	// searchLimit, when set, ends the search of NextFrom with the year that
+	// many years after the year it starts from, as robfig/cron does, and the
+	// search of PrevBefore with the year that many years before
+	searchLimit int
+	// calendars exclude days the fields would otherwise select, see WithCalendar
+	calendars []Calendar
+	// businessDays keeps the schedule to the days that are neither on the weekend
+	// nor holidays, rolling the other days it selects, see WithBusinessDays
+	businessDays  bool
+	roll          Roll
+	holidays      []Calendar
+	weekend       set[uint8]
+	customWeekend bool
+	// notBefore and notAfter, when set, bound the activations, see WithNotBefore
+	notBefore time.Time
+	notAfter  time.Time
 }
 
 /*
 UseLocal will set the cron schedule calculations to use
 the local time of the system it is running on
+
+Deprecated: UseLocal modifies a Cron that may be shared between goroutines,
+use Parse with WithLocation(time.Local) instead.
 */
 func (c *Cron) UseLocal() {
-	c.utc = false
+	c.loc = time.Local
 }
 
 /*
-NextFrom accepts a time in which it will calculate the next activation time after
+String returns the schedule in its canonical form, parsing the
+result with the same options yields an identical schedule
+*/
+func (c *Cron) String() string {
+	parts := make([]string, 0, 6)
+	if c.seconds {
+		parts = append(parts, formatWrappedCronPart(c.second, 0, 59, true))
+	}
+	parts = append(parts,
+		formatWrappedCronPart(c.minute, 0, 59, true),
+		formatWrappedCronPart(c.hour, 0, 23, true),
+		joinCronPart(formatWrappedCronPart(c.day, 1, 31, c.dayStar), c.formatDayRules()),
+		formatWrappedCronPart(c.month, 1, 12, true),
+		joinCronPart(formatWrappedCronPart(c.weekday, 0, 6, c.weekdayStar), c.formatWeekdayRules(0)),
+	)
+	if c.years {
+		parts = append(parts, formatYearPart(c.year))
+	}
+	return strings.Join(parts, " ")
+}
+
+/*
+NextFrom accepts a time in which it will calculate the next activation time after.
+If the schedule can never activate, or not after from, the zero time is returned
 */
 func (c *Cron) NextFrom(from time.Time) time.Time {
-	if c.utc {
-		from = from.UTC()
+	if from.Before(c.notBefore) {
+		from = c.notBefore.Add(-time.Nanosecond)
+	}
+	from = from.In(c.loc)
+	resolution := c.resolution()
+	limit := from.AddDate(searchYears, 0, 0)
+	if c.searchLimit > 0 {
+		limit = startOfDay(from.Year()+c.searchLimit+1, time.January, 1, c.loc)
+	}
+	if !c.notAfter.IsZero() && c.notAfter.Before(limit) {
+		limit = c.notAfter.Add(time.Nanosecond)
 	}
-	nextTime := from.Add(time.Minute)
+	nextTime := from.Truncate(resolution).Add(resolution)
 
-	for {
-		if c.isTime(nextTime) {
-			break
+	for nextTime.Before(limit) {
+		year, month, day := nextTime.Date()
+		switch {
+		case !c.businessDays && !c.year.contains(year):
+			if last := c.year.last(); year > last {
+				return time.Time{}
+			}
+			// Skip to the start of next year
+			nextTime = startOfDay(year+1, time.January, 1, c.loc)
+		case !c.businessDays && !c.month.contains(uint8(month)):
+			// Skip to the start of next month
+			nextTime = startOfDay(year, month+1, 1, c.loc)
+		case !c.isDay(nextTime):
+			// Skip to the start of the next day
+			nextTime = startOfDay(year, month, day+1, c.loc)
+		case !c.hour.contains(uint8(nextTime.Hour())):
+			// Skip to the start of the next hour, unless a daylight saving
+			// transition makes the wall clock jump within it
+			nextTime = skipMinutes(nextTime.Truncate(time.Minute), 60-nextTime.Minute())
+		case !c.minute.contains(uint8(nextTime.Minute())):
+			nextTime = nextTime.Truncate(time.Minute).Add(time.Minute)
+		case !c.second.contains(uint8(nextTime.Second())):
+			nextTime = nextTime.Add(time.Second)
+		default:
+			return nextTime
 		}
-
-		nextTime = nextTime.Add(time.Minute)
 	}
 
-	return nextTime
+	return time.Time{}
 }
 
 /*
@@ -59,53 +157,139 @@ func (c *Cron) Prev() time.Time {
 }
 
 /*
-PrevBefore accepts a time in which it will calculate the previous activation time before now
+PrevBefore accepts a time in which it will calculate the previous activation time before.
+If the schedule can never activate, or not before before, the zero time is returned
 */
 func (c *Cron) PrevBefore(before time.Time) time.Time {
-	if c.utc {
-		before = before.UTC()
+	if !c.notAfter.IsZero() && before.After(c.notAfter) {
+		before = c.notAfter.Add(time.Nanosecond)
+	}
+	before = before.In(c.loc)
+	resolution := c.resolution()
+	limit := before.AddDate(-searchYears, 0, 0)
+	if c.searchLimit > 0 {
+		limit = startOfDay(before.Year()-c.searchLimit, time.January, 1, c.loc)
+	}
+	if c.notBefore.After(limit) {
+		limit = c.notBefore
+	}
+	prevTime := before.Truncate(resolution)
+	if prevTime.Equal(before) {
+		prevTime = prevTime.Add(-1 * resolution)
 	}
-	prevTime := before.Add(-1 * time.Minute)
 
-	for {
-		if c.isTime(prevTime) {
-			break
+	for !prevTime.Before(limit) {
+		year, month, day := prevTime.Date()
+		switch {
+		case !c.businessDays && !c.year.contains(year):
+			if first := c.year.first(); year < first {
+				return time.Time{}
+			}
+			// Skip to the end of the previous year
+			prevTime = startOfDay(year, time.January, 1, c.loc).Add(-1 * resolution)
+		case !c.businessDays && !c.month.contains(uint8(month)):
+			// Skip to the end of the previous month
+			prevTime = startOfDay(year, month, 1, c.loc).Add(-1 * resolution)
+		case !c.isDay(prevTime):
+			// Skip to the end of the previous day
+			prevTime = startOfDay(year, month, day, c.loc).Add(-1 * resolution)
+		case !c.hour.contains(uint8(prevTime.Hour())):
+			// Skip to the end of the previous hour, unless a daylight
+			// saving transition makes the wall clock jump within it
+			prevTime = skipMinutes(prevTime.Truncate(time.Minute), -1*(prevTime.Minute()+1)).Add(time.Minute - resolution)
+		case !c.minute.contains(uint8(prevTime.Minute())):
+			prevTime = prevTime.Truncate(time.Minute).Add(-1 * resolution)
+		case !c.second.contains(uint8(prevTime.Second())):
+			prevTime = prevTime.Add(-1 * time.Second)
+		default:
+			return prevTime
 		}
-
-		prevTime = prevTime.Add(-1 * time.Minute)
 	}
 
-	return prevTime
+	return time.Time{}
 }
 
 /*
 Now will tell you it is currently time for a cron schedule to activate
 */
 func (c *Cron) Now() bool {
-	now := c.now()
-	if c.utc {
-		now = now.UTC()
-	}
-	return c.isTime(now)
+	return c.isTime(c.now())
 }
 
 func (c *Cron) isTime(time time.Time) bool {
-	if c.minute.contains(uint8(time.Minute())) &&
+	if c.inBounds(time) &&
+		c.second.contains(uint8(time.Second())) &&
+		c.minute.contains(uint8(time.Minute())) &&
 		c.hour.contains(uint8(time.Hour())) &&
-		c.day.contains(uint8(time.Day())) &&
-		c.month.contains(uint8(time.Month())) &&
-		c.weekday.contains(uint8(time.Weekday())) {
+		(c.businessDays || c.month.contains(uint8(time.Month())) && c.year.contains(time.Year())) &&
+		c.isDay(time) {
 		return true
 	}
 	return false
 }
 
-var timeNow = time.Now
+// isDay reports whether the schedule activates on the day of t, leaving out the
+// days excluded by the calendars. With business days the days are rolled and the
+// month and year fields are checked here, otherwise the callers check those first.
+func (c *Cron) isDay(time time.Time) bool {
+	if c.businessDays {
+		return c.isRolledDay(time) && !c.excluded(time)
+	}
+	return c.fieldsMatchDay(time) && !c.excluded(time)
+}
+
+// fieldsMatchDay combines the day of the month and day of the week according to dayMatch
+func (c *Cron) fieldsMatchDay(time time.Time) bool {
+	dayMatches := c.day.contains(uint8(time.Day())) || c.dayRulesMatch(time)
+	weekdayMatches := c.weekday.contains(uint8(time.Weekday())) || c.weekdayRulesMatch(time)
+	if c.dayMatch == DayMatchOr && !c.dayStar && !c.weekdayStar {
+		return dayMatches || weekdayMatches
+	}
+	return dayMatches && weekdayMatches
+}
+
+// resolution is the smallest step between two activations of the schedule
+func (c *Cron) resolution() time.Duration {
+	if c.seconds {
+		return time.Second
+	}
+	return time.Minute
+}
+
+// skipMinutes moves t by n minutes when the UTC offset is the same at both ends,
+// otherwise the wall clock does not advance with it and t moves by a single minute
+func skipMinutes(t time.Time, n int) time.Time {
+	skipped := t.Add(time.Duration(n) * time.Minute)
+	_, offset := t.Zone()
+	_, skippedOffset := skipped.Zone()
+	if offset == skippedOffset {
+		return skipped
+	}
+	if n < 0 {
+		return t.Add(-1 * time.Minute)
+	}
+	return t.Add(time.Minute)
+}
+
+// startOfDay returns the first instant of a date. time.Date resolves a midnight
+// skipped by daylight saving into the previous day, so step forward until the
+// date is reached.
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	year, month, day = time.Date(year, month, day, 12, 0, 0, 0, loc).Date()
+	for {
+		y, m, d := start.Date()
+		if y == year && m == month && d == day {
+			return start
+		}
+		start = start.Add(time.Minute)
+	}
+}
 
 func (c *Cron) now() time.Time {
-	now := timeNow().Truncate(1 * time.Minute)
-	if c.utc {
-		now = now.UTC()
+	clock := c.clock
+	if clock == nil {
+		clock = SystemClock
 	}
-	return now
+	return clock.Now().Truncate(c.resolution()).In(c.loc)
 }
@@ -3,6 +3,9 @@ package cron
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -18,15 +21,15 @@ func TestCron_UseLocal(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
+		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			timeNow = func() time.Time {
-				return time.Now().Local()
-			}
-			cron, err := Parse(tt.schedule)
+			t.Parallel()
+			clock := NewFakeClock(time.Now().Local())
+			cron, err := Parse(tt.schedule, WithClock(clock))
 			assert.NoError(t, err)
 			cron.UseLocal()
-			assert.Equal(t, timeNow().Location(), cron.now().Location())
-			assert.True(t, timeNow().Truncate(1*time.Minute).Equal(cron.now()))
+			assert.Equal(t, clock.Now().Location(), cron.now().Location())
+			assert.True(t, clock.Now().Truncate(1*time.Minute).Equal(cron.now()))
 		})
 	}
 }
@@ -70,11 +73,10 @@ func TestCron_Next(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
+		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			timeNow = func() time.Time {
-				return time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
-			}
-			cron, err := Parse(tt.schedule)
+			t.Parallel()
+			cron, err := Parse(tt.schedule, WithClock(NewFakeClock(time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC))))
 			assert.NoError(t, err)
 			next := cron.Next()
 			fmt.Println(tt.want)
@@ -123,11 +125,10 @@ func TestCron_Prev(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
+		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			timeNow = func() time.Time {
-				return time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
-			}
-			cron, err := Parse(tt.schedule)
+			t.Parallel()
+			cron, err := Parse(tt.schedule, WithClock(NewFakeClock(time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC))))
 			assert.NoError(t, err)
 			prev := cron.Prev()
 			assert.True(t, tt.want.Equal(prev))
@@ -179,11 +180,10 @@ func TestCron_Now(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
+		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			timeNow = func() time.Time {
-				return time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
-			}
-			cron, err := Parse(tt.schedule)
+			t.Parallel()
+			cron, err := Parse(tt.schedule, WithClock(NewFakeClock(time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC))))
 			assert.NoError(t, err)
 			now := cron.Now()
 			assert.Equal(t, tt.want, now)
@@ -191,6 +191,195 @@ func TestCron_Now(t *testing.T) {
 	}
 }
 
+func TestCron_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		want     string
+	}{
+		{
+			name:     "base cron",
+			schedule: "* * * * *",
+			want:     "* * * * *",
+		},
+		{
+			name:     "single digit cron",
+			schedule: "1 1 1 1 1",
+			want:     "1 1 1 1 1",
+		},
+		{
+			name:     "full ranges become wildcards, except the day fields",
+			schedule: "0-59 0-23 1-31 1-12 0-6",
+			want:     "* * 1-31 * 0-6",
+		},
+		{
+			name:     "steps",
+			schedule: "*/5 */6 */10 */3 */2",
+			want:     "*/5 */6 */10 */3 */2",
+		},
+		{
+			name:     "lists become ranges",
+			schedule: "1,2,3,7 4,5 1,31 6 0,6",
+			want:     "1-3,7 4-5 1,31 6 0,6",
+		},
+		{
+			name:     "range with step",
+			schedule: "1-4/2 1-4/2 1-4/2 1-4/2 1-4/2",
+			want:     "1,3 1,3 1,3 1,3 1,3",
+		},
+		{
+			name:     "lists with range with step",
+			schedule: "1-2,*/5 * * * *",
+			want:     "0-2,5,10,15,20,25,30,35,40,45,50,55 * * * *",
+		},
+		{
+			name:     "names and day rules",
+			schedule: "0 12 15W,L-2,L JAN-MAR MON-FRI,SATL,1#2",
+			want:     "0 12 L,L-2,15W 1-3 1-5,6L,1#2",
+		},
+		{
+			name:     "steps from a single value and sunday as 7",
+			schedule: "5/15 0 * * 7",
+			want:     "5,20,35,50 0 * * 0",
+		},
+		{
+			name:     "ranges wrapping around the end of the field",
+			schedule: "55-2 22-2 28-3 NOV-FEB FRI-MON",
+			want:     "55-2 22-2 28-3 11-2 5-1",
+		},
+		{
+			name:     "wrapping ranges among other values",
+			schedule: "0 9,22-1 * * SAT-SUN",
+			want:     "0 9,22-1 * * 0,6",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := Parse(tt.schedule)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cron.String())
+		})
+	}
+}
+
+func TestCron_NextFrom_Never(t *testing.T) {
+	cron, err := Parse("0 0 30 2 *")
+	assert.NoError(t, err)
+	from := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+	assert.True(t, cron.NextFrom(from).IsZero())
+	assert.True(t, cron.PrevBefore(from).IsZero())
+}
+
+// randomSchedule builds a random but valid schedule, mixing every syntax Parse accepts
+func randomSchedule(r *rand.Rand) string {
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+	parts := make([]string, 0, len(bounds))
+	for field, b := range bounds {
+		min, max := b[0], b[1]
+		items := make([]string, 1+r.Intn(3))
+		for i := range items {
+			step := 1 + r.Intn(max)
+			// Start ranges on a multiple of the step, so stepped ranges select at least one value
+			lo := min + step*r.Intn((max-min)/step+1)
+			hi := lo + r.Intn(max-lo+1)
+			// The day fields also have rules of their own
+			if field == 2 && r.Intn(4) == 0 {
+				items[i] = [...]string{"L", "L-" + strconv.Itoa(r.Intn(31)), "LW", strconv.Itoa(lo) + "W"}[r.Intn(4)]
+				continue
+			}
+			if field == 4 && r.Intn(4) == 0 {
+				items[i] = [...]string{strconv.Itoa(lo) + "L", fmt.Sprintf("%d#%d", lo, 1+r.Intn(5))}[r.Intn(2)]
+				continue
+			}
+			switch r.Intn(8) {
+			case 0:
+				items[i] = "*"
+			case 1:
+				items[i] = "*/" + strconv.Itoa(step)
+			case 2:
+				items[i] = fmt.Sprintf("%d-%d", lo, hi)
+			case 3:
+				items[i] = fmt.Sprintf("%d-%d/%d", lo, hi, step)
+			case 4:
+				// Wrap around the end of the field, which always selects its start
+				items[i] = fmt.Sprintf("%d-%d/%d", hi, lo, step)
+			case 5:
+				items[i] = fmt.Sprintf("%d/%d", lo, step)
+			default:
+				items[i] = strconv.Itoa(lo)
+			}
+		}
+		parts = append(parts, strings.Join(items, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+var propertyLocations = []string{
+	"UTC",
+	"America/New_York",
+	"Europe/London",
+	"Asia/Kolkata",
+	"Australia/Lord_Howe",
+	"America/Sao_Paulo",
+}
+
+// assertActivationProperties checks the invariants every schedule must uphold around from
+func assertActivationProperties(t *testing.T, cron *Cron, from time.Time) {
+	next := cron.NextFrom(from)
+	if !next.IsZero() {
+		assert.True(t, next.After(from), "%s: next %s is not after %s", cron, next, from)
+		assert.True(t, cron.isTime(next), "%s: next %s is not an activation", cron, next)
+		prev := cron.PrevBefore(next)
+		assert.False(t, prev.After(from), "%s: prev %s of next %s is after %s", cron, prev, next, from)
+	}
+
+	prev := cron.PrevBefore(from)
+	if !prev.IsZero() {
+		assert.True(t, prev.Before(from), "%s: prev %s is not before %s", cron, prev, from)
+		assert.True(t, cron.isTime(prev), "%s: prev %s is not an activation", cron, prev)
+		// The activation after prev can lie beyond the search bounds from prev
+		if next := cron.NextFrom(prev); !next.IsZero() {
+			assert.False(t, next.Before(from), "%s: next %s of prev %s is before %s", cron, next, prev, from)
+		}
+	}
+}
+
+func TestCron_Properties(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		schedule := randomSchedule(r)
+		loc, err := time.LoadLocation(propertyLocations[r.Intn(len(propertyLocations))])
+		assert.NoError(t, err)
+		cron, err := Parse(schedule, WithLocation(loc))
+		if !assert.NoError(t, err, schedule) {
+			continue
+		}
+
+		from := time.Unix(r.Int63n(4102444800), r.Int63n(int64(time.Second))).In(loc)
+		assertActivationProperties(t, cron, from)
+	}
+}
+
+func FuzzCron_NextFrom(f *testing.F) {
+	f.Add("* * * * *", int64(1687026180), uint8(0))
+	f.Add("30 1 * * *", int64(1699160400), uint8(1))
+	f.Add("0 2 * 3 0", int64(1678579200), uint8(1))
+	f.Add("*/15 0 1 * *", int64(1696118400), uint8(4))
+	f.Add("0 0 29 2 1", int64(951782400), uint8(5))
+	f.Fuzz(func(t *testing.T, schedule string, unix int64, zone uint8) {
+		loc, err := time.LoadLocation(propertyLocations[int(zone)%len(propertyLocations)])
+		assert.NoError(t, err)
+		cron, err := Parse(schedule, WithLocation(loc))
+		if err != nil {
+			return
+		}
+
+		// Keep times within years the time package and the search bounds handle comfortably
+		from := time.Unix(unix%4102444800, 0).In(loc)
+		assertActivationProperties(t, cron, from)
+	})
+}
+
 var result *Cron
 
 func benchmarkNow(schedule string, b *testing.B) {
@@ -0,0 +1,282 @@
+/*
+Package crontab reads whole crontab files, the user crontabs edited with
+crontab -e as well as /etc/crontab and the files of /etc/cron.d, which add
+a user field before the command.
+
+Parse reads a crontab into its entries, variables and comments, while Load
+reads it into a Document whose entries can be added, removed and re-scheduled
+without disturbing the lines that were not edited.
+*/
+package crontab
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/frisbm/cron"
+)
+
+/*
+Crontab is the contents of a crontab file, in the order they appear
+*/
+type Crontab struct {
+	Entries   []Entry
+	Variables []Variable
+	Comments  []Comment
+}
+
+/*
+Entry is a line of a crontab that runs a command
+*/
+type Entry struct {
+	// Line is the line number of the entry, counting from 1
+	Line int
+	// Spec is the schedule as it was written, i.e. "*/5 * * * *" or "@daily"
+	Spec string
+	// Schedule is when the command runs, it is nil for @reboot
+	Schedule *cron.Cron
+	// Reboot is set for @reboot, which runs the command once when cron starts
+	Reboot bool
+	// User is the user the command runs as, it is only read with WithUserField
+	User string
+	// Command is the rest of the line, as it was written
+	Command string
+	// Env is the environment set by the variables above the entry, it is
+	// shared between entries and must not be modified
+	Env map[string]string
+}
+
+/*
+Variable is a line of a crontab that sets an environment variable, i.e. MAILTO=ops
+*/
+type Variable struct {
+	Line  int
+	Name  string
+	Value string
+}
+
+/*
+Comment is a line of a crontab starting with #, Text is the rest of the line
+*/
+type Comment struct {
+	Line int
+	Text string
+}
+
+/*
+LineError describes why a line of a crontab could not be read
+*/
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+/*
+Option configures how Parse reads a crontab
+*/
+type Option func(c *config)
+
+type config struct {
+	userField bool
+	loc       *time.Location
+}
+
+/*
+WithUserField will expect a user field between the schedule and the command,
+as in /etc/crontab and the files of /etc/cron.d
+*/
+func WithUserField() Option {
+	return func(c *config) {
+		c.userField = true
+	}
+}
+
+/*
+WithLocation will set the location the schedules are calculated in until a
+CRON_TZ variable changes it, by default time.Local is used as cron does
+*/
+func WithLocation(loc *time.Location) Option {
+	return func(c *config) {
+		c.loc = loc
+	}
+}
+
+/*
+Parse reads a crontab, line by line. Lines that cannot be read are left out
+and reported together in the returned error, as a *LineError each, so that one
+bad line does not hide the rest of the file. The Crontab is returned even when
+there is an error, unless the reader itself fails.
+
+Each entry is a schedule in the form read by cron.Parse, or one of its macros or
+@reboot, followed by the command. As in Vixie cron, a day that matches either of
+the day of the month and the day of the week fields is part of the schedule when
+both are restricted. A variable assignment (NAME=value) applies to
+the entries below it, and CRON_TZ sets the location of their schedules.
+*/
+func Parse(r io.Reader, opts ...Option) (*Crontab, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseLines(splitLines(string(data)), newConfig(opts))
+}
+
+// newConfig returns the config with the options applied, in the local time zone by default
+func newConfig(opts []Option) config {
+	conf := config{loc: time.Local}
+	for _, opt := range opts {
+		opt(&conf)
+	}
+	return conf
+}
+
+// splitLines splits a crontab into its lines, each with the line ending it has.
+// Lines may be of any length, unlike with a bufio.Scanner
+func splitLines(data string) []string {
+	var lines []string
+	for rest := data; rest != ""; {
+		end := strings.IndexByte(rest, '\n') + 1
+		if end == 0 {
+			end = len(rest)
+		}
+		lines = append(lines, rest[:end])
+		rest = rest[end:]
+	}
+	return lines
+}
+
+// parseLines reads the lines of a crontab as Parse does
+func parseLines(lines []string, conf config) (*Crontab, error) {
+	crontab := &Crontab{}
+	env := map[string]string{}
+	loc := conf.loc
+	var errs []error
+
+	for i, raw := range lines {
+		line := i + 1
+		text := strings.TrimSpace(raw)
+		switch {
+		case text == "":
+			continue
+		case strings.HasPrefix(text, "#"):
+			crontab.Comments = append(crontab.Comments, Comment{Line: line, Text: text[1:]})
+			continue
+		}
+
+		if name, value, ok := parseVariable(text); ok {
+			if name == "CRON_TZ" {
+				zone, err := time.LoadLocation(value)
+				if err != nil {
+					errs = append(errs, &LineError{Line: line, Err: err})
+					continue
+				}
+				loc = zone
+			}
+			// Entries keep the environment they were given, so copy it before changing it
+			next := make(map[string]string, len(env)+1)
+			for k, v := range env {
+				next[k] = v
+			}
+			next[name] = value
+			env = next
+			crontab.Variables = append(crontab.Variables, Variable{Line: line, Name: name, Value: value})
+			continue
+		}
+
+		entry, err := parseEntry(text, conf.userField, loc)
+		if err != nil {
+			errs = append(errs, &LineError{Line: line, Err: err})
+			continue
+		}
+		entry.Line = line
+		entry.Env = env
+		crontab.Entries = append(crontab.Entries, entry)
+	}
+	return crontab, errors.Join(errs...)
+}
+
+// parseVariable reads a NAME=value assignment, the value may be quoted
+func parseVariable(text string) (string, string, bool) {
+	end := 0
+	for end < len(text) && isNameByte(text[end], end == 0) {
+		end++
+	}
+	name := text[:end]
+	rest := strings.TrimLeft(text[end:], " \t")
+	if name == "" || !strings.HasPrefix(rest, "=") {
+		return "", "", false
+	}
+
+	value := strings.TrimSpace(rest[1:])
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return name, value, true
+}
+
+func isNameByte(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && c >= '0' && c <= '9')
+}
+
+// parseEntry reads the schedule, the optional user and the command of an entry
+func parseEntry(text string, userField bool, loc *time.Location) (Entry, error) {
+	fields := 5
+	if strings.HasPrefix(text, "@") {
+		fields = 1
+	}
+
+	spec := make([]string, 0, fields)
+	rest := text
+	for len(spec) < fields {
+		var field string
+		field, rest = nextField(rest)
+		if field == "" {
+			return Entry{}, errors.New("expected a schedule and a command")
+		}
+		spec = append(spec, field)
+	}
+
+	entry := Entry{Spec: strings.Join(spec, " ")}
+	if entry.Spec == "@reboot" {
+		entry.Reboot = true
+	} else {
+		schedule, err := cron.Parse(entry.Spec, cron.WithLocation(loc), cron.WithDayMatch(cron.DayMatchOr))
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.Schedule = schedule
+	}
+
+	if userField {
+		entry.User, rest = nextField(rest)
+		if entry.User == "" {
+			return Entry{}, errors.New("expected a user and a command")
+		}
+	}
+	entry.Command = strings.TrimLeft(rest, " \t")
+	if entry.Command == "" {
+		return Entry{}, errors.New("expected a command")
+	}
+	return entry, nil
+}
+
+// nextField returns the first whitespace separated field of text and the rest of it
+func nextField(text string) (string, string) {
+	text = strings.TrimLeft(text, " \t")
+	end := strings.IndexAny(text, " \t")
+	if end < 0 {
+		return text, ""
+	}
+	return text[:end], text[end:]
+}
@@ -0,0 +1,174 @@
+package crontab
+
+import (
+	"errors"
+	"github.com/frisbm/cron"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const userCrontab = `# Edit this file to introduce tasks to be run by cron.
+SHELL=/bin/bash
+MAILTO = "ops@example.com"
+
+*/5 * * * * /usr/local/bin/poll --quiet
+0 9 * * 1-5	echo "standup"  >> /tmp/standup.log
+@daily /usr/local/bin/rotate
+@reboot /usr/local/bin/warm-cache
+
+CRON_TZ=America/New_York
+30 8 * * * /usr/local/bin/report
+`
+
+func TestParse(t *testing.T) {
+	crontab, err := Parse(strings.NewReader(userCrontab), WithLocation(time.UTC))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, []Comment{{Line: 1, Text: " Edit this file to introduce tasks to be run by cron."}}, crontab.Comments)
+	assert.Equal(t, []Variable{
+		{Line: 2, Name: "SHELL", Value: "/bin/bash"},
+		{Line: 3, Name: "MAILTO", Value: "ops@example.com"},
+		{Line: 10, Name: "CRON_TZ", Value: "America/New_York"},
+	}, crontab.Variables)
+
+	if !assert.Len(t, crontab.Entries, 5) {
+		return
+	}
+	env := map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com"}
+	tests := []struct {
+		line     int
+		spec     string
+		schedule string
+		reboot   bool
+		command  string
+		env      map[string]string
+	}{
+		{line: 5, spec: "*/5 * * * *", schedule: "*/5 * * * *", command: "/usr/local/bin/poll --quiet", env: env},
+		{line: 6, spec: "0 9 * * 1-5", schedule: "0 9 * * 1-5", command: `echo "standup"  >> /tmp/standup.log`, env: env},
+		{line: 7, spec: "@daily", schedule: "0 0 * * *", command: "/usr/local/bin/rotate", env: env},
+		{line: 8, spec: "@reboot", reboot: true, command: "/usr/local/bin/warm-cache", env: env},
+		{
+			line: 11, spec: "30 8 * * *", schedule: "30 8 * * *", command: "/usr/local/bin/report",
+			env: map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com", "CRON_TZ": "America/New_York"},
+		},
+	}
+	for i, tt := range tests {
+		entry := crontab.Entries[i]
+		assert.Equal(t, tt.line, entry.Line)
+		assert.Equal(t, tt.spec, entry.Spec)
+		assert.Equal(t, tt.reboot, entry.Reboot)
+		assert.Equal(t, tt.command, entry.Command)
+		assert.Equal(t, tt.env, entry.Env)
+		assert.Empty(t, entry.User)
+		if tt.reboot {
+			assert.Nil(t, entry.Schedule)
+			continue
+		}
+		if assert.NotNil(t, entry.Schedule) {
+			assert.Equal(t, tt.schedule, entry.Schedule.String())
+		}
+	}
+
+	// CRON_TZ changes the location of the entries below it
+	from := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+	assert.True(t, time.Date(2023, 6, 19, 9, 0, 0, 0, time.UTC).Equal(crontab.Entries[1].Schedule.NextFrom(from)))
+	assert.True(t, time.Date(2023, 6, 18, 12, 30, 0, 0, time.UTC).Equal(crontab.Entries[4].Schedule.NextFrom(from)))
+}
+
+func TestParse_UserField(t *testing.T) {
+	const systemCrontab = "17 *\t* * *\troot    cd / && run-parts --report /etc/cron.hourly\n" +
+		"@reboot www-data /usr/bin/warm\n" +
+		"25 6 * * *\n"
+	crontab, err := Parse(strings.NewReader(systemCrontab), WithUserField())
+
+	var lineErr *LineError
+	if assert.ErrorAs(t, err, &lineErr) {
+		assert.Equal(t, 3, lineErr.Line)
+	}
+	if assert.Len(t, crontab.Entries, 2) {
+		assert.Equal(t, "17 * * * *", crontab.Entries[0].Spec)
+		assert.Equal(t, "root", crontab.Entries[0].User)
+		assert.Equal(t, "cd / && run-parts --report /etc/cron.hourly", crontab.Entries[0].Command)
+		assert.Equal(t, time.Local, crontab.Entries[0].Schedule.NextFrom(time.Now()).Location())
+		assert.Equal(t, "www-data", crontab.Entries[1].User)
+		assert.True(t, crontab.Entries[1].Reboot)
+	}
+}
+
+func TestParse_DayMatch(t *testing.T) {
+	crontab, err := Parse(strings.NewReader("0 0 1 * 1 /bin/x\n0 0 1 * * /bin/y\n"), WithLocation(time.UTC))
+	if !assert.NoError(t, err) || !assert.Len(t, crontab.Entries, 2) {
+		return
+	}
+
+	// Either day field matches when both are restricted, only the first of the month otherwise
+	from := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC), crontab.Entries[0].Schedule.NextFrom(from))
+	assert.Equal(t, time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC), crontab.Entries[1].Schedule.NextFrom(from))
+}
+
+func TestParse_SteppedRanges(t *testing.T) {
+	// Steps count from the start of their range, as in Vixie cron
+	tests := []struct {
+		line string
+		from time.Time
+		want time.Time
+	}{
+		{line: "0 0 1-10/3 * * /bin/x", from: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC), want: time.Date(2023, 6, 4, 0, 0, 0, 0, time.UTC)},
+		{line: "0 0 1-10/3 * * /bin/x", from: time.Date(2023, 6, 8, 0, 0, 0, 0, time.UTC), want: time.Date(2023, 6, 10, 0, 0, 0, 0, time.UTC)},
+		{line: "0 9 * * 1-5/2 /bin/x", from: time.Date(2023, 6, 5, 12, 0, 0, 0, time.UTC), want: time.Date(2023, 6, 7, 9, 0, 0, 0, time.UTC)},
+		{line: "2-59/5 * * * * /bin/x", from: time.Date(2023, 6, 5, 12, 0, 0, 0, time.UTC), want: time.Date(2023, 6, 5, 12, 2, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			crontab, err := Parse(strings.NewReader(tt.line+"\n"), WithLocation(time.UTC))
+			if !assert.NoError(t, err) || !assert.Len(t, crontab.Entries, 1) {
+				return
+			}
+			assert.Equal(t, tt.want, crontab.Entries[0].Schedule.NextFrom(tt.from))
+		})
+	}
+}
+
+func TestParse_LineErrors(t *testing.T) {
+	const broken = "* * * * * /bin/ok\n" +
+		"61 * * * * /bin/minute\n" +
+		"* * * * /bin/short\n" +
+		"@fortnightly /bin/macro\n" +
+		"CRON_TZ=Mars/Olympus_Mons\n" +
+		"* * * * *\n" +
+		"0 0 * * * /bin/also-ok\n"
+	crontab, err := Parse(strings.NewReader(broken))
+
+	var lines []int
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var lineErr *LineError
+		if assert.ErrorAs(t, err, &lineErr) {
+			lines = append(lines, lineErr.Line)
+		}
+	}
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, lines)
+	assert.ErrorIs(t, err, cron.InvalidCronSchedule)
+
+	// The lines that could be read are still returned
+	if assert.Len(t, crontab.Entries, 2) {
+		assert.Equal(t, 1, crontab.Entries[0].Line)
+		assert.Equal(t, 7, crontab.Entries[1].Line)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("disk on fire")
+}
+
+func TestParse_ReaderError(t *testing.T) {
+	crontab, err := Parse(failingReader{})
+	assert.EqualError(t, err, "disk on fire")
+	assert.Nil(t, crontab)
+}
@@ -0,0 +1,210 @@
+package crontab
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/frisbm/cron"
+)
+
+/*
+Document is an editable crontab. It keeps every line as it was read, so a
+Document that is not edited is written back byte for byte, and an edit only
+changes the bytes of the entry it is made to.
+
+Entries are addressed by their line number, which moves when lines above
+them are added or removed; Entries returns the current line numbers.
+*/
+type Document struct {
+	// lines are the lines of the crontab, each with the line ending it was read with
+	lines []string
+	opts  []Option
+}
+
+/*
+Load reads a crontab into a Document. Lines that cannot be read are kept in the
+Document as they are and reported in the returned error as Parse does, the
+Document is returned even when there is an error, unless the reader itself fails
+*/
+func Load(r io.Reader, opts ...Option) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{lines: splitLines(string(data)), opts: opts}
+	_, err = doc.parse()
+	return doc, err
+}
+
+/*
+Entries returns the entries of the Document with their current line numbers,
+the lines that cannot be read are left out as with Parse
+*/
+func (d *Document) Entries() []Entry {
+	crontab, _ := d.parse()
+	return crontab.Entries
+}
+
+/*
+Add appends an entry to the end of the Document, written from the Spec, User and
+Command of entry, and returns its line number. User is required with WithUserField
+*/
+func (d *Document) Add(entry Entry) (int, error) {
+	if err := validSpec(entry.Spec); err != nil {
+		return 0, err
+	}
+	if strings.ContainsAny(entry.Command, "\r\n") || strings.TrimSpace(entry.Command) == "" {
+		return 0, errors.New("the command must be a single line that is not empty")
+	}
+
+	fields := []string{entry.Spec}
+	if d.config().userField {
+		if entry.User == "" || strings.ContainsAny(entry.User, " \t\r\n") {
+			return 0, errors.New("the user must be a single field that is not empty")
+		}
+		fields = append(fields, entry.User)
+	}
+	fields = append(fields, entry.Command)
+
+	// The last line may not have been ended, it is ended as the first line was
+	ending := d.lineEnding()
+	if last := len(d.lines) - 1; last >= 0 && !strings.HasSuffix(d.lines[last], "\n") {
+		d.lines[last] += ending
+	}
+	d.lines = append(d.lines, strings.Join(fields, " ")+ending)
+	return len(d.lines), nil
+}
+
+/*
+Remove removes the entry on the line, the lines below it move up by one
+*/
+func (d *Document) Remove(line int) error {
+	if err := d.checkEntry(line); err != nil {
+		return err
+	}
+	d.lines = append(d.lines[:line-1], d.lines[line:]...)
+	return nil
+}
+
+/*
+SetSchedule replaces the schedule of the entry on the line with spec,
+leaving the rest of the line as it is
+*/
+func (d *Document) SetSchedule(line int, spec string) error {
+	if err := d.checkEntry(line); err != nil {
+		return err
+	}
+	if err := validSpec(spec); err != nil {
+		return err
+	}
+
+	text, ending := splitEnding(d.lines[line-1])
+	start, end := specSpan(text)
+	d.lines[line-1] = text[:start] + spec + text[end:] + ending
+	return nil
+}
+
+/*
+SetCommand replaces the command of the entry on the line,
+leaving the schedule and the user as they are
+*/
+func (d *Document) SetCommand(line int, command string) error {
+	if err := d.checkEntry(line); err != nil {
+		return err
+	}
+	if strings.ContainsAny(command, "\r\n") || strings.TrimSpace(command) == "" {
+		return errors.New("the command must be a single line that is not empty")
+	}
+
+	text, ending := splitEnding(d.lines[line-1])
+	_, end := specSpan(text)
+	if d.config().userField {
+		_, rest := nextField(text[end:])
+		end = len(text) - len(rest)
+	}
+	// Keep the whitespace that separated the command from the fields before it
+	end += len(text[end:]) - len(strings.TrimLeft(text[end:], " \t"))
+	d.lines[line-1] = text[:end] + command + ending
+	return nil
+}
+
+/*
+Bytes returns the contents of the Document
+*/
+func (d *Document) Bytes() []byte {
+	return []byte(d.String())
+}
+
+/*
+String returns the contents of the Document
+*/
+func (d *Document) String() string {
+	return strings.Join(d.lines, "")
+}
+
+/*
+WriteTo writes the contents of the Document to w
+*/
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, d.String())
+	return int64(n), err
+}
+
+// parse reads the current contents of the Document as Parse does
+func (d *Document) parse() (*Crontab, error) {
+	return parseLines(d.lines, d.config())
+}
+
+func (d *Document) config() config {
+	return newConfig(d.opts)
+}
+
+// checkEntry fails unless there is an entry on the line
+func (d *Document) checkEntry(line int) error {
+	for _, entry := range d.Entries() {
+		if entry.Line == line {
+			return nil
+		}
+	}
+	return fmt.Errorf("line %d is not an entry", line)
+}
+
+// lineEnding returns the line ending of the first line, or \n when there is none
+func (d *Document) lineEnding() string {
+	if len(d.lines) > 0 && strings.HasSuffix(d.lines[0], "\r\n") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// validSpec fails unless spec is a schedule an entry may have
+func validSpec(spec string) error {
+	if spec == "@reboot" {
+		return nil
+	}
+	_, err := cron.Parse(spec, cron.WithDayMatch(cron.DayMatchOr))
+	return err
+}
+
+// splitEnding separates a line from its line ending
+func splitEnding(line string) (string, string) {
+	text := strings.TrimRight(line, "\r\n")
+	return text, line[len(text):]
+}
+
+// specSpan returns where the schedule of an entry starts and ends within its line
+func specSpan(text string) (int, int) {
+	rest := strings.TrimLeft(text, " \t")
+	start := len(text) - len(rest)
+	fields := 5
+	if strings.HasPrefix(rest, "@") {
+		fields = 1
+	}
+	for i := 0; i < fields; i++ {
+		_, rest = nextField(rest)
+	}
+	return start, len(text) - len(rest)
+}
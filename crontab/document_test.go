@@ -0,0 +1,146 @@
+package crontab
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestLoad_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		crontab string
+	}{
+		{name: "user crontab", crontab: userCrontab},
+		{name: "windows line endings", crontab: "# nightly\r\nMAILTO=ops\r\n0 0 * * *\t/bin/nightly\r\n"},
+		{name: "no final line ending", crontab: "*/5 * * * * /bin/poll"},
+		{name: "blank lines and odd spacing", crontab: "\n\n  0   9 * * 1-5    /bin/standup  \n\t\n"},
+		{name: "lines that cannot be read", crontab: "61 * * * * /bin/broken\nnot a line\n"},
+		{name: "empty", crontab: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, _ := Load(strings.NewReader(tt.crontab))
+			if !assert.NotNil(t, doc) {
+				return
+			}
+			assert.Equal(t, tt.crontab, doc.String())
+
+			var b bytes.Buffer
+			n, err := doc.WriteTo(&b)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tt.crontab)), n)
+			assert.Equal(t, tt.crontab, b.String())
+			assert.Equal(t, tt.crontab, string(doc.Bytes()))
+		})
+	}
+}
+
+func TestDocument_Edit(t *testing.T) {
+	const crontab = "# Poll often\n" +
+		"*/5  * * * *\t/usr/local/bin/poll --quiet   # keep quiet\n" +
+		"MAILTO=ops\n" +
+		"@daily   /usr/local/bin/rotate\n"
+	doc, err := Load(strings.NewReader(crontab))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Re-scheduling leaves the spacing and the command as they were
+	assert.NoError(t, doc.SetSchedule(2, "*/10 * * * *"))
+	assert.NoError(t, doc.SetSchedule(4, "0 3 * * *"))
+	assert.Equal(t, "# Poll often\n"+
+		"*/10 * * * *\t/usr/local/bin/poll --quiet   # keep quiet\n"+
+		"MAILTO=ops\n"+
+		"0 3 * * *   /usr/local/bin/rotate\n", doc.String())
+
+	assert.NoError(t, doc.SetCommand(4, "/usr/local/bin/rotate --compress"))
+	assert.Equal(t, "0 3 * * *   /usr/local/bin/rotate --compress\n", strings.SplitAfter(doc.String(), "\n")[3])
+
+	line, err := doc.Add(Entry{Spec: "@reboot", Command: "/usr/local/bin/warm-cache"})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, line)
+
+	assert.NoError(t, doc.Remove(2))
+	assert.Equal(t, "# Poll often\n"+
+		"MAILTO=ops\n"+
+		"0 3 * * *   /usr/local/bin/rotate --compress\n"+
+		"@reboot /usr/local/bin/warm-cache\n", doc.String())
+
+	entries := doc.Entries()
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, 3, entries[0].Line)
+		assert.Equal(t, "0 3 * * *", entries[0].Spec)
+		assert.Equal(t, map[string]string{"MAILTO": "ops"}, entries[0].Env)
+		assert.Equal(t, 4, entries[1].Line)
+		assert.True(t, entries[1].Reboot)
+	}
+}
+
+func TestDocument_AddEndsLastLine(t *testing.T) {
+	doc, err := Load(strings.NewReader("MAILTO=ops\r\n0 0 * * * /bin/nightly"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = doc.Add(Entry{Spec: "0 12 * * *", Command: "/bin/noon"})
+	assert.NoError(t, err)
+	assert.Equal(t, "MAILTO=ops\r\n0 0 * * * /bin/nightly\r\n0 12 * * * /bin/noon\r\n", doc.String())
+}
+
+func TestDocument_UserField(t *testing.T) {
+	doc, err := Load(strings.NewReader("17 * * * *  root  run-parts /etc/cron.hourly\n"), WithUserField())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, doc.SetCommand(1, "run-parts --report /etc/cron.hourly"))
+	_, err = doc.Add(Entry{Spec: "@reboot", User: "www-data", Command: "/usr/bin/warm"})
+	assert.NoError(t, err)
+	assert.Equal(t, "17 * * * *  root  run-parts --report /etc/cron.hourly\n"+
+		"@reboot www-data /usr/bin/warm\n", doc.String())
+
+	_, err = doc.Add(Entry{Spec: "@reboot", Command: "/usr/bin/warm"})
+	assert.Error(t, err)
+}
+
+func TestDocument_LongLine(t *testing.T) {
+	// Lines are not limited to the 64KiB of a bufio.Scanner
+	text := "# " + strings.Repeat("x", 70*1024) + "\n0 0 * * * /bin/nightly\n"
+	doc, err := Load(strings.NewReader(text))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, text, doc.String())
+
+	entries := doc.Entries()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, 2, entries[0].Line)
+	}
+	assert.NoError(t, doc.SetCommand(2, "/bin/weekly"))
+	assert.NoError(t, doc.SetSchedule(2, "0 0 * * 0"))
+	assert.NoError(t, doc.Remove(2))
+	assert.Error(t, doc.Remove(1))
+}
+
+func TestDocument_EditError(t *testing.T) {
+	doc, err := Load(strings.NewReader("# comment\n0 0 * * * /bin/nightly\n"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	before := doc.String()
+
+	assert.Error(t, doc.Remove(1))
+	assert.Error(t, doc.Remove(3))
+	assert.Error(t, doc.SetSchedule(1, "0 1 * * *"))
+	assert.Error(t, doc.SetSchedule(2, "0 24 * * *"))
+	assert.Error(t, doc.SetSchedule(2, "0  1 * * *"))
+	assert.Error(t, doc.SetCommand(2, "/bin/one\n/bin/two"))
+	_, err = doc.Add(Entry{Spec: "* * * *", Command: "/bin/short"})
+	assert.Error(t, err)
+	_, err = doc.Add(Entry{Spec: "* * * * *"})
+	assert.Error(t, err)
+
+	// Failed edits leave the document as it was
+	assert.Equal(t, before, doc.String())
+}
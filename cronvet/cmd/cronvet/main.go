@@ -0,0 +1,16 @@
+/*
+Command cronvet reports invalid constant cron schedules, it can be
+run on its own or through go vet:
+
+	go vet -vettool=$(which cronvet) ./...
+*/
+package main
+
+import (
+	"github.com/frisbm/cron/cronvet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(cronvet.Analyzer)
+}
@@ -0,0 +1,178 @@
+/*
+Package cronvet provides an analyzer that reports invalid schedules
+passed as constants to cron.Parse, cron.MustParse, cron.ParseQuartz,
+cron.ParseEventBridge, cron.ParseOnCalendar, cron.ParseKubernetes and cron.ParseRRule,
+so they are caught at build time instead of when the program first runs.
+*/
+package cronvet
+
+import (
+	"errors"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"time"
+
+	"github.com/frisbm/cron"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const cronPath = "github.com/frisbm/cron"
+
+/*
+Analyzer checks constant schedules given to cron.Parse, cron.MustParse, cron.ParseQuartz,
+cron.ParseEventBridge, cron.ParseOnCalendar, cron.ParseKubernetes and cron.ParseRRule
+*/
+var Analyzer = &analysis.Analyzer{
+	Name:     "cronvet",
+	Doc:      "report invalid constant schedules passed to cron.Parse, cron.MustParse, cron.ParseQuartz, cron.ParseEventBridge, cron.ParseOnCalendar, cron.ParseKubernetes and cron.ParseRRule",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// parsers are the functions whose schedules are checked, by name
+var parsers = map[string]func(string, ...cron.Option) (*cron.Cron, error){
+	"Parse":            cron.Parse,
+	"MustParse":        cron.Parse,
+	"ParseQuartz":      cron.ParseQuartz,
+	"ParseEventBridge": parseEventBridge,
+	"ParseOnCalendar":  cron.ParseOnCalendar,
+	"ParseKubernetes":  parseKubernetes,
+	"ParseRRule":       parseRRule,
+}
+
+// optionArgs is where the options begin among the arguments of a parser, when not
+// right after the schedule
+var optionArgs = map[string]int{
+	"ParseKubernetes": 2,
+	"ParseRRule":      2,
+}
+
+// parseEventBridge adapts cron.ParseEventBridge to the parsers, only its error is checked
+func parseEventBridge(expression string, opts ...cron.Option) (*cron.Cron, error) {
+	_, err := cron.ParseEventBridge(expression, opts...)
+	return nil, err
+}
+
+// parseKubernetes adapts cron.ParseKubernetes to the parsers, the time zone is
+// checked at run time so only the schedule is checked
+func parseKubernetes(schedule string, opts ...cron.Option) (*cron.Cron, error) {
+	_, err := cron.ParseKubernetes(schedule, nil, opts...)
+	return nil, err
+}
+
+// parseRRule adapts cron.ParseRRule to the parsers, whether a rule is valid does
+// not depend on its DTSTART
+func parseRRule(rule string, opts ...cron.Option) (*cron.Cron, error) {
+	return cron.ParseRRule(rule, time.Unix(0, 0), opts...)
+}
+
+// neutralOptions do not change which schedules are valid
+var neutralOptions = map[string]bool{
+	"WithLocation": true,
+	"WithDayMatch": true,
+	"WithClock":    true,
+	"WithRand":     true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		name := cronFunc(pass, call.Fun)
+		parse, ok := parsers[name]
+		first, set := optionArgs[name]
+		if !set {
+			first = 1
+		}
+		if !ok || len(call.Args) < first || call.Ellipsis.IsValid() {
+			return
+		}
+
+		tv, ok := pass.TypesInfo.Types[call.Args[0]]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			return
+		}
+		schedule := constant.StringVal(tv.Value)
+
+		opts, ok := scheduleOptions(pass, call.Args[first:])
+		if !ok {
+			return
+		}
+
+		_, err := parse(schedule, opts...)
+		if err == nil {
+			return
+		}
+		pass.Reportf(schedulePos(call.Args[0], schedule, err), "%v", err)
+	})
+
+	return nil, nil
+}
+
+// cronFunc returns the name of the cron package function called by fun, if any
+func cronFunc(pass *analysis.Pass, fun ast.Expr) string {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(fun).(type) {
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.Ident:
+		ident = fun
+	default:
+		return ""
+	}
+	fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != cronPath {
+		return ""
+	}
+	return fn.Name()
+}
+
+// scheduleOptions maps the options of a call onto the options to check the
+// schedule with, it fails when an option cannot be known until run time
+func scheduleOptions(pass *analysis.Pass, args []ast.Expr) ([]cron.Option, bool) {
+	var opts []cron.Option
+	for _, arg := range args {
+		call, ok := ast.Unparen(arg).(*ast.CallExpr)
+		if !ok {
+			return nil, false
+		}
+		name := cronFunc(pass, call.Fun)
+		switch {
+		case name == "WithSeconds":
+			opts = append(opts, cron.WithSeconds())
+		case name == "WithYears":
+			opts = append(opts, cron.WithYears())
+		case name == "WithHashKey":
+			// Any key makes H valid, whatever it resolves to
+			opts = append(opts, cron.WithHashKey("cronvet"))
+		case neutralOptions[name]:
+		default:
+			return nil, false
+		}
+	}
+	return opts, true
+}
+
+// schedulePos points at the failing field when the schedule is a plain
+// string literal, otherwise at the start of the expression
+func schedulePos(expr ast.Expr, schedule string, err error) token.Pos {
+	var parseErr *cron.ParseError
+	lit, ok := ast.Unparen(expr).(*ast.BasicLit)
+	if !ok || !errors.As(err, &parseErr) || parseErr.Field == "" {
+		return expr.Pos()
+	}
+	// The literal must spell the schedule out byte for byte, without escapes
+	if lit.Value[1:len(lit.Value)-1] != schedule {
+		return expr.Pos()
+	}
+	if unquoted, uerr := strconv.Unquote(lit.Value); uerr != nil || unquoted != schedule {
+		return expr.Pos()
+	}
+	return lit.Pos() + 1 + token.Pos(parseErr.Offset)
+}
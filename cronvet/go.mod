@@ -0,0 +1,14 @@
+module github.com/frisbm/cron/cronvet
+
+go 1.22.0
+
+require (
+	github.com/frisbm/cron v1.2.0
+	golang.org/x/tools v0.28.0
+)
+
+require (
+	golang.org/x/exp v0.0.0-20230522175609-2e198f4a06a1 // indirect
+	golang.org/x/mod v0.22.0 // indirect
+	golang.org/x/sync v0.10.0 // indirect
+)
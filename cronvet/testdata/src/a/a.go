@@ -0,0 +1,69 @@
+package a
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/frisbm/cron"
+)
+
+const hourly = "0 * * * *"
+
+var (
+	valid      = cron.MustParse("*/5 * * * *")
+	validConst = cron.MustParse(hourly)
+	invalid    = cron.MustParse("* * * * 8") // want `invalid cron schedule "\* \* \* \* 8": weekday field: 8 is outside the range 0-7`
+	fields     = cron.MustParse("* * * *")   // want `invalid cron schedule "\* \* \* \*": expected 5 fields separated by single spaces`
+)
+
+func parse(schedule string, opts ...cron.Option) {
+	_, _ = cron.Parse(schedule)
+	_, _ = cron.Parse("0 9 * * 1-5", cron.WithLocation(time.Local))
+	_, _ = cron.Parse("0 9 * * 1-5", cron.WithSeconds()) // want `expected 6 fields`
+	_, _ = cron.Parse("30 0 9 * * 1-5", cron.WithSeconds())
+	_, _ = cron.Parse("0 25 * * *", cron.WithLocation(time.UTC)) // want `hour field`
+	_, _ = cron.Parse("0 25 * * *", opts...)
+	_, _ = cron.Parse("0 25 * * *", opts[0])
+	_, _ = cron.Parse(hourly + " *") // want `expected 5 fields`
+}
+
+func quartz() {
+	_, _ = cron.ParseQuartz("0 0/5 14,18 ? * MON-FRI *")
+	_, _ = cron.ParseQuartz("0 0/5 14,18 * * MON-FRI") // want `\? must be used in exactly one of the day`
+	_, _ = cron.Parse("0 0 12 * * 2024", cron.WithYears())
+	_, _ = cron.Parse("0 0 12 * * 1969", cron.WithYears()) // want `year field`
+}
+
+func eventBridge() {
+	_, _ = cron.ParseEventBridge("cron(0/5 8-17 ? * MON-FRI *)")
+	_, _ = cron.ParseEventBridge("rate(5 minutes)")
+	_, _ = cron.ParseEventBridge("cron(0 18 ? * 8 *)") // want `weekday field: 8 is outside the range 1-7`
+	_, _ = cron.ParseEventBridge("rate(1 hours)")      // want `unit "hours" does not agree with the value 1`
+}
+
+func onCalendar() {
+	_, _ = cron.ParseOnCalendar("Mon..Fri *-*-* 09:00:00")
+	_, _ = cron.ParseOnCalendar("Mon..Fri *-*-* 24:00:00") // want `hour field: 24 is outside the range 0-23`
+}
+
+func kubernetes(timeZone *string) {
+	_, _ = cron.ParseKubernetes("0 3 * * MON-FRI", timeZone)
+	_, _ = cron.ParseKubernetes("@every 90m", nil, cron.WithLocation(time.UTC))
+	_, _ = cron.ParseKubernetes("0 3 * * 7", timeZone) // want `weekday field: end of range \(7\) above maximum \(6\): 7`
+}
+
+func rrule(dtstart time.Time) {
+	_, _ = cron.ParseRRule("FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=9", dtstart)
+	_, _ = cron.ParseRRule("FREQ=DAILY;BYHOUR=24", dtstart) // want `BYHOUR field: "24" is outside the range 0-23`
+}
+
+func hashed(job string) {
+	_, _ = cron.Parse("H/15 * * * *", cron.WithHashKey(job))
+	_, _ = cron.Parse("H/15 * * * *")                           // want `H requires a hash key`
+	_, _ = cron.Parse("H(0-60) * * * *", cron.WithHashKey(job)) // want `minute field: 60 is outside the range 0-59`
+}
+
+func random(r *rand.Rand) {
+	_, _ = cron.Parse("0~30 * * * *", cron.WithRand(r))
+	_, _ = cron.Parse("30~0 * * * *", cron.WithRand(r)) // want `minute field: range min cannot be greater than range max`
+}
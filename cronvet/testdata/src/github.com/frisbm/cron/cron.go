@@ -0,0 +1,43 @@
+// Package cron is a stub of github.com/frisbm/cron for the analyzer tests
+package cron
+
+import (
+	"math/rand"
+	"time"
+)
+
+type Cron struct{}
+
+type Option func(c *Cron)
+
+type DayMatch uint8
+
+func Parse(schedule string, opts ...Option) (*Cron, error) { return &Cron{}, nil }
+
+func MustParse(schedule string, opts ...Option) *Cron { return &Cron{} }
+
+func WithSeconds() Option { return nil }
+
+func WithLocation(loc *time.Location) Option { return nil }
+
+func WithDayMatch(dayMatch DayMatch) Option { return nil }
+
+func ParseQuartz(expression string, opts ...Option) (*Cron, error) { return &Cron{}, nil }
+
+func WithYears() Option { return nil }
+
+type Schedule interface{}
+
+func ParseEventBridge(expression string, opts ...Option) (Schedule, error) { return &Cron{}, nil }
+
+func ParseOnCalendar(expression string, opts ...Option) (*Cron, error) { return &Cron{}, nil }
+
+func ParseKubernetes(schedule string, timeZone *string, opts ...Option) (Schedule, error) {
+	return &Cron{}, nil
+}
+
+func ParseRRule(rule string, dtstart time.Time, opts ...Option) (*Cron, error) { return &Cron{}, nil }
+
+func WithHashKey(key string) Option { return nil }
+
+func WithRand(random *rand.Rand) Option { return nil }
@@ -0,0 +1,110 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dayRulesMatch reports whether t falls on a day selected by the L and W
+// rules of the day of the month field
+func (c *Cron) dayRulesMatch(t time.Time) bool {
+	year, month, day := t.Date()
+	last := daysIn(year, month)
+
+	if c.lastDays.contains(uint8(last - day)) {
+		return true
+	}
+	if c.lastWeekday && day == nearestWeekday(year, month, last) {
+		return true
+	}
+	if c.nearestWeekdays.bits == 0 {
+		return false
+	}
+	// The nearest weekday is never more than two days from its day, i.e.
+	// 1W falls on Monday the 3rd when the 1st is a Saturday
+	for nearest := day - 2; nearest <= day+2; nearest++ {
+		if nearest >= 1 && nearest <= last && c.nearestWeekdays.contains(uint8(nearest)) &&
+			nearestWeekday(year, month, nearest) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// weekdayRulesMatch reports whether t falls on a day selected by the L
+// and # rules of the day of the week field
+func (c *Cron) weekdayRulesMatch(t time.Time) bool {
+	weekday := uint8(t.Weekday())
+	year, month, day := t.Date()
+
+	if c.lastWeekdays.contains(weekday) && day+7 > daysIn(year, month) {
+		return true
+	}
+	nth := uint8(day-1) / 7
+	return c.nthWeekdays.contains(nth*7 + weekday)
+}
+
+// daysIn returns the number of days in the month of the year
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday returns the weekday (Monday to Friday) nearest to the
+// day, without leaving its month
+func nearestWeekday(year int, month time.Month, day int) int {
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == daysIn(year, month) {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+// formatDayRules renders the L and W rules of the day of the month field
+func (c *Cron) formatDayRules() string {
+	var rules []string
+	for before := 0; before <= 30; before++ {
+		if !c.lastDays.contains(uint8(before)) {
+			continue
+		}
+		if before == 0 {
+			rules = append(rules, "L")
+		} else {
+			rules = append(rules, "L-"+strconv.Itoa(before))
+		}
+	}
+	if c.lastWeekday {
+		rules = append(rules, "LW")
+	}
+	for day := 1; day <= 31; day++ {
+		if c.nearestWeekdays.contains(uint8(day)) {
+			rules = append(rules, strconv.Itoa(day)+"W")
+		}
+	}
+	return strings.Join(rules, ",")
+}
+
+// formatWeekdayRules renders the L and # rules of the day of the week field,
+// numbering the days of the week from base
+func (c *Cron) formatWeekdayRules(base int) string {
+	var rules []string
+	for weekday := 0; weekday <= 6; weekday++ {
+		if c.lastWeekdays.contains(uint8(weekday)) {
+			rules = append(rules, strconv.Itoa(weekday+base)+"L")
+		}
+	}
+	for i := 0; i < 35; i++ {
+		if c.nthWeekdays.contains(uint8(i)) {
+			rules = append(rules, strconv.Itoa(i%7+base)+"#"+strconv.Itoa(i/7+1))
+		}
+	}
+	return strings.Join(rules, ",")
+}
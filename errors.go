@@ -1,8 +1,40 @@
 package cron
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	EmptyCronSchedule   = errors.New("cron schedule is empty")
 	InvalidCronSchedule = errors.New("invalid cron schedule")
+	// UnrepresentableSchedule is returned when a schedule cannot be written in another syntax
+	UnrepresentableSchedule = errors.New("schedule cannot be represented")
 )
+
+/*
+ParseError describes why a schedule could not be parsed, it matches
+InvalidCronSchedule with errors.Is
+*/
+type ParseError struct {
+	// Schedule is the schedule that failed to parse
+	Schedule string
+	// Field is the name of the field that failed to parse, i.e. "minute",
+	// it is empty when the schedule as a whole is malformed
+	Field string
+	// Offset is the byte offset of the field within Schedule
+	Offset int
+	// Err is the underlying reason the schedule failed to parse
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("invalid cron schedule %q: %v", e.Schedule, e.Err)
+	}
+	return fmt.Sprintf("invalid cron schedule %q: %s field: %v", e.Schedule, e.Field, e.Err)
+}
+
+func (e *ParseError) Unwrap() []error {
+	return []error{InvalidCronSchedule, e.Err}
+}
@@ -0,0 +1,217 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var eventBridge = dialect{
+	weekdayBase: 1,
+}
+
+/*
+ParseEventBridge takes an AWS EventBridge schedule expression, either
+cron(0 12 ? * MON-FRI *) or rate(5 minutes), and returns a Schedule if the
+expression is valid; otherwise, it returns an error. A cron() expression
+returns a *Cron and a rate() expression returns a *Rate. Options may be
+given to change how the schedule is calculated, the location stands in for
+the time zone of the EventBridge schedule.
+
+A cron() expression has six fields, numbers the days of the week from 1 (SUN)
+to 7 (SAT), a step counts from the value before it (i.e. 0/5), and ? must be
+used in exactly one of the day of month and day of week fields:
+
+* [0-59]      (* , / -)
+
+* [0-23]      (* , / -)
+
+* [1-31]      (* , / - ? L W)
+
+* [1-12]      (* , / -)    JAN-DEC
+
+* [1-7]       (* , / - ? L #)    SUN-SAT
+
+* [1970-2199] (* , / -)
+
+A rate() expression is a positive number of minutes, hours or days, singular
+for a value of 1 (i.e. rate(1 hour), rate(6 hours)). EventBridge counts the
+rate from when the schedule was created, the returned Rate counts from the Unix
+epoch; use NewRate with its Interval to count from another time.
+*/
+func ParseEventBridge(expression string, opts ...Option) (Schedule, error) {
+	trimmed := strings.TrimSpace(expression)
+	if trimmed == "" {
+		return nil, EmptyCronSchedule
+	}
+	if !strings.HasSuffix(trimmed, ")") {
+		return nil, &ParseError{Schedule: expression, Err: errors.New("expected cron(...) or rate(...)")}
+	}
+
+	// Offset the fields from the start of the expression rather than from the parenthesis
+	offset := strings.Index(expression, "(") + 1
+	switch {
+	case strings.HasPrefix(trimmed, "cron("):
+		cron, err := parseEventBridgeCron(expression, expression[offset:offset+len(trimmed)-len("cron()")], offset, opts)
+		if err != nil {
+			return nil, err
+		}
+		return cron, nil
+	case strings.HasPrefix(trimmed, "rate("):
+		rate, err := parseEventBridgeRate(expression, trimmed[len("rate("):len(trimmed)-1], opts)
+		if err != nil {
+			return nil, err
+		}
+		return rate, nil
+	}
+	return nil, &ParseError{Schedule: expression, Err: errors.New("expected cron(...) or rate(...)")}
+}
+
+// parseEventBridgeCron parses the fields of a cron() expression, offset is
+// where the fields begin within the expression
+func parseEventBridgeCron(expression, fields string, offset int, opts []Option) (*Cron, error) {
+	conf := newConfig(opts)
+	cron := conf.Cron
+
+	var cronParts [maxFields]string
+	var offsets [maxFields]int
+	parts := splitFields(strings.ToUpper(fields), &cronParts, &offsets)
+	if parts != 6 {
+		return nil, &ParseError{
+			Schedule: expression,
+			Err:      errors.New("expected 6 fields"),
+		}
+	}
+	for i := range offsets[:parts] {
+		offsets[i] += offset
+	}
+	cron.seconds = false
+	cron.years = true
+
+	if err := checkQuestionMark(expression, cronParts[2], cronParts[4]); err != nil {
+		return nil, err
+	}
+
+	if err := eventBridge.parseFields(conf, expression, cronParts[:parts], offsets[:parts]); err != nil {
+		return nil, err
+	}
+	return cron, nil
+}
+
+// rateUnits are the units of a rate() expression by their singular name
+var rateUnits = map[string]time.Duration{
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+// parseEventBridgeRate parses the value and unit of a rate() expression
+func parseEventBridgeRate(expression, rate string, opts []Option) (*Rate, error) {
+	fields := strings.Fields(rate)
+	if len(fields) != 2 {
+		return nil, &ParseError{Schedule: expression, Err: errors.New("expected a value and a unit")}
+	}
+
+	value, err := strconv.ParseUint(fields[0], 10, 32)
+	if err != nil || value == 0 {
+		return nil, &ParseError{Schedule: expression, Err: fmt.Errorf("%q is not a positive whole number", fields[0])}
+	}
+
+	singular, plural := strings.CutSuffix(fields[1], "s")
+	unit, ok := rateUnits[singular]
+	if !ok {
+		return nil, &ParseError{Schedule: expression, Err: fmt.Errorf("unknown unit %q, expected minutes, hours or days", fields[1])}
+	}
+	if plural == (value == 1) {
+		return nil, &ParseError{Schedule: expression, Err: fmt.Errorf("unit %q does not agree with the value %d", fields[1], value)}
+	}
+
+	cron := newCron(opts)
+	return NewRate(time.Duration(value)*unit, time.Unix(0, 0).In(cron.loc), cron.clock)
+}
+
+/*
+EventBridge returns the schedule as an EventBridge cron() expression. The location
+of the Cron is not part of the expression, it is the time zone of the EventBridge
+schedule. An error matching UnrepresentableSchedule is returned when the schedule
+activates on seconds other than 0, restricts both the day of the month and the day
+of the week, excludes the days of a calendar, keeps to business days or is bounded
+by WithNotBefore or WithNotAfter
+*/
+func (c *Cron) EventBridge() (string, error) {
+	if c.second != newSet[uint8](0) {
+		return "", fmt.Errorf("%w in EventBridge: activates on seconds other than 0", UnrepresentableSchedule)
+	}
+	if len(c.calendars) > 0 {
+		return "", fmt.Errorf("%w in EventBridge: excludes the days of a calendar", UnrepresentableSchedule)
+	}
+	if c.businessDays {
+		return "", fmt.Errorf("%w in EventBridge: keeps to business days", UnrepresentableSchedule)
+	}
+	if !c.notBefore.IsZero() || !c.notAfter.IsZero() {
+		return "", fmt.Errorf("%w in EventBridge: is bounded to a window", UnrepresentableSchedule)
+	}
+
+	days := joinCronPart(formatCronPart(c.day, 1, 31, true), c.formatDayRules())
+	// EventBridge numbers the days of the week from 1
+	weekdays := joinCronPart(formatCronPart(set[uint8]{bits: c.weekday.bits << 1}, 1, 7, true), c.formatWeekdayRules(1))
+	anyDay, anyWeekday := days == "*", weekdays == "*"
+	switch {
+	case c.dayMatch == DayMatchOr && !c.dayStar && !c.weekdayStar && (anyDay || anyWeekday):
+		// Either field matching every day makes every day match
+		days, weekdays = "*", "?"
+	case c.dayMatch == DayMatchOr && !c.dayStar && !c.weekdayStar:
+		return "", fmt.Errorf("%w in EventBridge: either of the day fields may match", UnrepresentableSchedule)
+	case anyWeekday:
+		weekdays = "?"
+	case anyDay:
+		days = "?"
+	default:
+		return "", fmt.Errorf("%w in EventBridge: restricts both the day of month and day of week", UnrepresentableSchedule)
+	}
+
+	years := "*"
+	if c.years {
+		years = formatYearPart(c.year)
+	}
+
+	return "cron(" + strings.Join([]string{
+		stepFromValue(formatCronPart(c.minute, 0, 59, true), 0),
+		stepFromValue(formatCronPart(c.hour, 0, 23, true), 0),
+		stepFromValue(days, 1),
+		stepFromValue(formatCronPart(c.month, 1, 12, true), 1),
+		stepFromValue(weekdays, 1),
+		years,
+	}, " ") + ")", nil
+}
+
+/*
+EventBridge returns the Rate as an EventBridge rate() expression. EventBridge counts
+the rate from when the schedule is created rather than from the start of the Rate.
+An error matching UnrepresentableSchedule is returned when the interval is not a
+whole number of minutes
+*/
+func (r *Rate) EventBridge() (string, error) {
+	for _, unit := range []string{"day", "hour", "minute"} {
+		if r.interval%rateUnits[unit] != 0 {
+			continue
+		}
+		value := int64(r.interval / rateUnits[unit])
+		if value != 1 {
+			unit += "s"
+		}
+		return "rate(" + strconv.FormatInt(value, 10) + " " + unit + ")", nil
+	}
+	return "", fmt.Errorf("%w in EventBridge: %v is not a whole number of minutes", UnrepresentableSchedule, r.interval)
+}
+
+// stepFromValue writes a stepped wildcard (i.e. */5) as a step from the first
+// value of the field (i.e. 0/5), which is how EventBridge expects steps
+func stepFromValue(cronPart string, min int) string {
+	if step, ok := strings.CutPrefix(cronPart, "*/"); ok {
+		return strconv.Itoa(min) + "/" + step
+	}
+	return cronPart
+}
@@ -0,0 +1,218 @@
+package cron
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestParseEventBridge follows the examples of the EventBridge Scheduler documentation
+func TestParseEventBridge(t *testing.T) {
+	from := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		expression string
+		want       []time.Time
+	}{
+		{
+			name:       "10:15am every day",
+			expression: "cron(15 10 * * ? *)",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 10, 15, 0, 0, time.UTC),
+				time.Date(2023, 6, 19, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "6:00pm Monday through Friday",
+			expression: "cron(0 18 ? * MON-FRI *)",
+			want: []time.Time{
+				time.Date(2023, 6, 19, 18, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 20, 18, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "8:00am on the first day of the month",
+			expression: "cron(0 8 1 * ? *)",
+			want: []time.Time{
+				time.Date(2023, 7, 1, 8, 0, 0, 0, time.UTC),
+				time.Date(2023, 8, 1, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every 10 minutes on weekdays",
+			expression: "cron(0/10 * ? * MON-FRI *)",
+			want: []time.Time{
+				time.Date(2023, 6, 19, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 19, 0, 10, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every 5 minutes between 8:00am and 5:55pm on weekdays",
+			expression: "cron(0/5 8-17 ? * MON-FRI *)",
+			want: []time.Time{
+				time.Date(2023, 6, 19, 8, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 19, 8, 5, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "9:00am on the first Monday of each month",
+			expression: "cron(0 9 ? * 2#1 *)",
+			want: []time.Time{
+				time.Date(2023, 7, 3, 9, 0, 0, 0, time.UTC),
+				time.Date(2023, 8, 7, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "noon on the last weekday of the month",
+			expression: "cron(0 12 LW * ? *)",
+			want: []time.Time{
+				time.Date(2023, 6, 30, 12, 0, 0, 0, time.UTC),
+				time.Date(2023, 7, 31, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "noon on the last Friday of the month during 2024",
+			expression: "cron(0 12 ? * 6L 2024)",
+			want: []time.Time{
+				time.Date(2024, 1, 26, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 23, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "surrounding whitespace",
+			expression: " cron(15 10  * * ? *) ",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every 5 minutes",
+			expression: "rate(5 minutes)",
+			want: []time.Time{
+				time.Date(2023, 6, 17, 18, 25, 0, 0, time.UTC),
+				time.Date(2023, 6, 17, 18, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every hour",
+			expression: "rate(1 hour)",
+			want: []time.Time{
+				time.Date(2023, 6, 17, 19, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 17, 20, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every 7 days",
+			expression: "rate(7 days)",
+			want: []time.Time{
+				time.Date(2023, 6, 22, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseEventBridge(tt.expression)
+			if !assert.NoError(t, err) {
+				return
+			}
+			next := from
+			for _, want := range tt.want {
+				next = schedule.NextFrom(next)
+				assert.True(t, want.Equal(next), "want %s, got %s", want, next)
+			}
+		})
+	}
+}
+
+func TestParseEventBridge_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "empty", expression: " "},
+		{name: "standard schedule", expression: "0 12 * * *"},
+		{name: "unknown form", expression: "at(2023-06-17T18:23:00)"},
+		{name: "unclosed", expression: "cron(0 12 * * ? *"},
+		{name: "five fields", expression: "cron(0 12 * * ?)"},
+		{name: "seven fields", expression: "cron(0 0 12 * * ? *)"},
+		{name: "no question mark", expression: "cron(0 12 * * * *)"},
+		{name: "two question marks", expression: "cron(0 12 ? * ? *)"},
+		{name: "weekday zero", expression: "cron(0 12 ? * 0 *)"},
+		{name: "year after 2199", expression: "cron(0 12 * * ? 2200)"},
+		{name: "rate without unit", expression: "rate(5)"},
+		{name: "rate of zero", expression: "rate(0 minutes)"},
+		{name: "negative rate", expression: "rate(-5 minutes)"},
+		{name: "rate in seconds", expression: "rate(30 seconds)"},
+		{name: "plural unit of one", expression: "rate(1 hours)"},
+		{name: "singular unit of many", expression: "rate(5 minute)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseEventBridge(tt.expression)
+			assert.Error(t, err)
+			assert.Nil(t, schedule)
+		})
+	}
+}
+
+func TestParseEventBridge_ParseError(t *testing.T) {
+	_, err := ParseEventBridge("cron(0 25 * * ? *)")
+	var parseErr *ParseError
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, "hour", parseErr.Field)
+		assert.Equal(t, 7, parseErr.Offset)
+	}
+}
+
+func TestCron_EventBridge(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		opts     []Option
+		want     string
+		wantErr  bool
+	}{
+		{name: "every minute", schedule: "* * * * *", want: "cron(* * * * ? *)"},
+		{name: "steps", schedule: "*/15 */2 */5 */3 *", want: "cron(0/15 0/2 1/5 1/3 ? *)"},
+		{name: "weekdays", schedule: "0 9 * * 1-5", want: "cron(0 9 ? * 2-6 *)"},
+		{name: "every other weekday", schedule: "0 9 * * */2", want: "cron(0 9 ? * 1/2 *)"},
+		{name: "day rules", schedule: "0 9 L,15W * *", want: "cron(0 9 L,15W * ? *)"},
+		{name: "weekday rules", schedule: "0 9 * * 5L,1#2", want: "cron(0 9 ? * 6L,2#2 *)"},
+		{name: "years", schedule: "0 9 1 1 * 2024-2026", opts: []Option{WithYears()}, want: "cron(0 9 1 1 ? 2024-2026)"},
+		{name: "zero seconds", schedule: "0 0 9 * * *", opts: []Option{WithSeconds()}, want: "cron(0 9 * * ? *)"},
+		{name: "or with a full day field", schedule: "0 9 1-31 * 1", opts: []Option{WithDayMatch(DayMatchOr)}, want: "cron(0 9 * * ? *)"},
+		{name: "seconds", schedule: "30 0 9 * * *", opts: []Option{WithSeconds()}, wantErr: true},
+		{name: "both day fields", schedule: "0 9 1 * 1", wantErr: true},
+		{name: "either day field", schedule: "0 9 1 * 1", opts: []Option{WithDayMatch(DayMatchOr)}, wantErr: true},
+		{name: "not before", schedule: "0 9 * * *", opts: []Option{WithNotBefore(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))}, wantErr: true},
+		{name: "not after", schedule: "0 9 * * *", opts: []Option{WithNotAfter(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := Parse(tt.schedule, tt.opts...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			got, err := cron.EventBridge()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, UnrepresentableSchedule), "want UnrepresentableSchedule, got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// The expression activates at the same times as the schedule
+			schedule, err := ParseEventBridge(got)
+			if assert.NoError(t, err, got) {
+				from := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+				for i := 0; i < 5; i++ {
+					want := cron.NextFrom(from)
+					from = schedule.NextFrom(from)
+					assert.True(t, want.Equal(from), "want %s, got %s", want, from)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+)
+
+// formatCronPart turns a set of values back into the most compact cron part
+// that parseCronPart will read back into the same set, a full set is only
+// written as * when wildcard is set. Ranges never wrap around the end of the
+// field, as not every syntax reads them
+func formatCronPart(timeSet set[uint8], min, max uint8, wildcard bool) string {
+	return formatRuns(timeSet, min, max, wildcard, false)
+}
+
+// formatWrappedCronPart is formatCronPart, except that values running on from the
+// end of the field to its start are written as a range that wraps around (i.e. 22-2)
+func formatWrappedCronPart(timeSet set[uint8], min, max uint8, wildcard bool) string {
+	return formatRuns(timeSet, min, max, wildcard, true)
+}
+
+func formatRuns(timeSet set[uint8], min, max uint8, wildcard, wrap bool) string {
+	// 1. Whole field, or the whole field stepped (i.e. */5)
+	for step := uint8(1); step < max-min; step++ {
+		full := newSet[uint8]()
+		full.addRange(min, max, step, min)
+		if full == timeSet && (step > 1 || wildcard) {
+			if step == 1 {
+				return "*"
+			}
+			return "*/" + strconv.Itoa(int(step))
+		}
+	}
+
+	// 2. Otherwise list runs of consecutive values as ranges
+	var runs [][2]int
+	for v := int(min); v <= int(max); v++ {
+		if !timeSet.contains(uint8(v)) {
+			continue
+		}
+		end := v
+		for end < int(max) && timeSet.contains(uint8(end+1)) {
+			end++
+		}
+		runs = append(runs, [2]int{v, end})
+		v = end
+	}
+
+	// 3. Join the runs at either end of the field, when together they make a range
+	// of three or more values; two values read better as a list (i.e. 0,6)
+	if last := len(runs) - 1; wrap && last > 0 && runs[0][0] == int(min) && runs[last][1] == int(max) &&
+		runs[0][1]-runs[0][0]+runs[last][1]-runs[last][0] >= 1 {
+		runs = append(runs[1:last], [2]int{runs[last][0], runs[0][1]})
+	}
+
+	var b strings.Builder
+	for _, run := range runs {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Itoa(run[0]))
+		if run[1] != run[0] {
+			b.WriteByte('-')
+			b.WriteString(strconv.Itoa(run[1]))
+		}
+	}
+	return b.String()
+}
+
+// joinCronPart appends the rules of a day field to its values, a field
+// made up only of rules has no values to render
+func joinCronPart(values, rules string) string {
+	switch {
+	case rules == "":
+		return values
+	case values == "":
+		return rules
+	}
+	return values + "," + rules
+}
+
+// formatYearPart turns a yearSet back into a cron part of the years field
+func formatYearPart(years yearSet) string {
+	if years == (yearSet{}) {
+		return "*"
+	}
+
+	var b strings.Builder
+	for year := minYear; year <= maxYear; year++ {
+		if !years.contains(year) {
+			continue
+		}
+		end := year
+		for end < maxYear && years.contains(end+1) {
+			end++
+		}
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Itoa(year))
+		if end > year {
+			b.WriteByte('-')
+			b.WriteString(strconv.Itoa(end))
+		}
+		year = end
+	}
+	return b.String()
+}
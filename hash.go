@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"errors"
+	"hash/fnv"
+	"strings"
+)
+
+// hashDayMax is the last day H picks for the day of the month, so that it
+// falls in every month
+const hashDayMax = 28
+
+// parseHash turns an H item of a cron part (H, H/15, H(0-29) or H(0-29)/10) into
+// the values it stands for, which are derived from the hash key of cron
+func (d dialect) parseHash(cron *config, base string, step uint8, hasStep bool, min, max uint8, part partType) (set[uint8], error) {
+	timeSet := newSet[uint8]()
+	if cron.hashKey == "" {
+		return timeSet, errors.New("H requires a hash key, see WithHashKey")
+	}
+
+	lower, upper := min, max
+	if part == day {
+		upper = hashDayMax
+	}
+	if base != "H" {
+		bounds, ok := strings.CutPrefix(base, "H(")
+		bounds, ok2 := strings.CutSuffix(bounds, ")")
+		first, last, isRange := strings.Cut(bounds, "-")
+		if !ok || !ok2 || !isRange {
+			return timeSet, errors.New("expected H, H/step or H(min-max)")
+		}
+		var err error
+		lower, err = parseValue(first, min, max, part)
+		if err != nil {
+			return timeSet, err
+		}
+		upper, err = parseValue(last, min, max, part)
+		if err != nil {
+			return timeSet, err
+		}
+		if lower > upper {
+			return timeSet, errors.New("range min cannot be greater than range max")
+		}
+	}
+
+	span := upper - lower + 1
+	if hasStep && step < span {
+		span = step
+	}
+	start := lower + uint8(cron.hash(part)%uint32(span))
+	if hasStep {
+		timeSet.addRange(start, upper, step, start)
+	} else {
+		timeSet.add(start)
+	}
+	return timeSet, nil
+}
+
+// hash derives a stable value for a field from the hash key, each field
+// hashes differently so that they are spread independently
+func (c *config) hash(part partType) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(c.hashKey))
+	h.Write([]byte{0})
+	h.Write([]byte(part))
+	return h.Sum32()
+}
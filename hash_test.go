@@ -0,0 +1,152 @@
+package cron
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParse_Hash(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		opts     []Option
+		check    func(t *testing.T, c *Cron)
+	}{
+		{
+			name:     "single value",
+			schedule: "H * * * *",
+			check: func(t *testing.T, c *Cron) {
+				assert.Len(t, values(c.minute, 0, 59), 1)
+			},
+		},
+		{
+			name:     "stepped",
+			schedule: "H/15 * * * *",
+			check: func(t *testing.T, c *Cron) {
+				minutes := values(c.minute, 0, 59)
+				if assert.Len(t, minutes, 4) {
+					assert.Less(t, minutes[0], 15)
+					assert.Equal(t, []int{minutes[0], minutes[0] + 15, minutes[0] + 30, minutes[0] + 45}, minutes)
+				}
+			},
+		},
+		{
+			name:     "range",
+			schedule: "H(0-29) * * * *",
+			check: func(t *testing.T, c *Cron) {
+				minutes := values(c.minute, 0, 59)
+				if assert.Len(t, minutes, 1) {
+					assert.LessOrEqual(t, minutes[0], 29)
+				}
+			},
+		},
+		{
+			name:     "stepped range",
+			schedule: "0 H(9-16)/4 * * *",
+			check: func(t *testing.T, c *Cron) {
+				hours := values(c.hour, 0, 23)
+				if assert.Len(t, hours, 2) {
+					assert.Equal(t, hours[0]+4, hours[1])
+					assert.GreaterOrEqual(t, hours[0], 9)
+				}
+			},
+		},
+		{
+			name:     "weekday names",
+			schedule: "0 0 * * H(MON-FRI)",
+			check: func(t *testing.T, c *Cron) {
+				weekdays := values(c.weekday, 0, 6)
+				if assert.Len(t, weekdays, 1) {
+					assert.GreaterOrEqual(t, weekdays[0], 1)
+					assert.LessOrEqual(t, weekdays[0], 5)
+				}
+			},
+		},
+		{
+			name:     "listed with other values",
+			schedule: "0 H,12 * * *",
+			check: func(t *testing.T, c *Cron) {
+				assert.True(t, c.hour.contains(12))
+			},
+		},
+		{
+			name:     "seconds",
+			schedule: "H H * * * *",
+			opts:     []Option{WithSeconds()},
+			check: func(t *testing.T, c *Cron) {
+				assert.Len(t, values(c.second, 0, 59), 1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithHashKey("nightly-backup")}, tt.opts...)
+			cron, err := Parse(tt.schedule, opts...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			tt.check(t, cron)
+
+			// The same key always resolves to the same schedule
+			again, err := Parse(tt.schedule, opts...)
+			if assert.NoError(t, err) {
+				assert.Equal(t, cron.String(), again.String())
+			}
+		})
+	}
+}
+
+func TestParse_HashSpread(t *testing.T) {
+	minutes := map[int]int{}
+	days := map[int]int{}
+	for i := 0; i < 300; i++ {
+		cron, err := Parse("H H H * *", WithHashKey(fmt.Sprintf("tenant-%d", i)))
+		if !assert.NoError(t, err) {
+			return
+		}
+		minutes[values(cron.minute, 0, 59)[0]]++
+		day := values(cron.day, 1, 31)[0]
+		assert.LessOrEqual(t, day, hashDayMax)
+		days[day]++
+	}
+	// 300 jobs over 60 minutes, none of which should take a large share
+	assert.Greater(t, len(minutes), 50)
+	for minute, count := range minutes {
+		assert.Less(t, count, 20, "minute %d", minute)
+	}
+	assert.Greater(t, len(days), 25)
+}
+
+func TestParse_HashError(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		opts     []Option
+	}{
+		{name: "no hash key", schedule: "H * * * *"},
+		{name: "backwards range", schedule: "H(30-10) * * * *", opts: []Option{WithHashKey("job")}},
+		{name: "range outside the field", schedule: "0 H(0-24) * * *", opts: []Option{WithHashKey("job")}},
+		{name: "unclosed range", schedule: "H(0-29 * * * *", opts: []Option{WithHashKey("job")}},
+		{name: "single value range", schedule: "H(5) * * * *", opts: []Option{WithHashKey("job")}},
+		{name: "zero step", schedule: "H/0 * * * *", opts: []Option{WithHashKey("job")}},
+		{name: "years", schedule: "0 0 1 1 * H", opts: []Option{WithHashKey("job"), WithYears()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.schedule, tt.opts...)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// values lists the values of a set between min and max
+func values(timeSet set[uint8], min, max int) []int {
+	var values []int
+	for v := min; v <= max; v++ {
+		if timeSet.contains(uint8(v)) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
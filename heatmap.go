@@ -0,0 +1,248 @@
+package cron
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Heatmap counts the activations of a collection of schedules by the day of the week,
+hour and minute they happen at in a location, to show when they cluster
+*/
+type Heatmap struct {
+	From     time.Time
+	To       time.Time
+	Location *time.Location
+	// Counts are the activations by weekday, hour and minute of the hour
+	Counts [7][24][60]int
+}
+
+/*
+NewHeatmap counts the activations of the schedules from from until to, not including it.
+Each schedule activates in its own location, its activations are counted by the weekday
+and hour they happen at in loc. The activations within a day are taken from the minute
+and hour fields, as with AnalyzeOverlap
+*/
+func NewHeatmap(schedules []*Cron, from, to time.Time, loc *time.Location) (*Heatmap, error) {
+	if !to.After(from) {
+		return nil, errors.New("heatmap window must end after it starts")
+	}
+	if loc == nil {
+		return nil, errors.New("heatmap requires a location")
+	}
+	heatmap := &Heatmap{From: from, To: to, Location: loc}
+	for i, schedule := range schedules {
+		if schedule == nil {
+			return nil, fmt.Errorf("schedule %d is nil", i)
+		}
+		start, end := schedule.window(from, to)
+		schedule.minuteMasks(from, to, func(hour int64, mask uint64) {
+			for ; mask != 0; mask &= mask - 1 {
+				minute := time.Unix((hour+int64(bits.TrailingZeros64(mask)))*60, 0).In(loc)
+				heatmap.Counts[minute.Weekday()][minute.Hour()][minute.Minute()] += schedule.activationsIn(minute, start, end)
+			}
+		})
+	}
+	return heatmap, nil
+}
+
+// activationsIn counts the activations within the minute starting at start, from from until to
+func (c *Cron) activationsIn(start, from, to time.Time) int {
+	if !start.Before(from) && !start.Add(time.Minute).After(to) {
+		return bits.OnesCount64(c.second.bits)
+	}
+	n := 0
+	for second := 0; second < 60; second++ {
+		t := start.Add(time.Duration(second) * time.Second)
+		if c.second.contains(uint8(second)) && !t.Before(from) && t.Before(to) {
+			n++
+		}
+	}
+	return n
+}
+
+/*
+Hour returns the activations within the hour of the day of the week
+*/
+func (h *Heatmap) Hour(weekday time.Weekday, hour int) int {
+	total := 0
+	for _, n := range h.Counts[weekday][hour] {
+		total += n
+	}
+	return total
+}
+
+/*
+Minute returns the activations at the minute of the hour, over every hour
+*/
+func (h *Heatmap) Minute(minute int) int {
+	total := 0
+	for weekday := range h.Counts {
+		for hour := range h.Counts[weekday] {
+			total += h.Counts[weekday][hour][minute]
+		}
+	}
+	return total
+}
+
+// heatmapBar is the width of the longest bar of the minute histogram of WriteText
+const heatmapBar = 50
+
+/*
+WriteText writes the heatmap as a table of the activations by weekday and hour,
+followed by a histogram of the activations by minute of the hour
+*/
+func (h *Heatmap) WriteText(w io.Writer) error {
+	most, mostMinute := h.most()
+	width := len(strconv.Itoa(most))
+	if width < 2 {
+		width = 2
+	}
+
+	var b strings.Builder
+	b.WriteString("   ")
+	for hour := 0; hour < 24; hour++ {
+		fmt.Fprintf(&b, " %*s", width, fmt.Sprintf("%02d", hour))
+	}
+	b.WriteString("\n")
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		b.WriteString(weekday.String()[:3])
+		for hour := 0; hour < 24; hour++ {
+			cell := "."
+			if n := h.Hour(weekday, hour); n > 0 {
+				cell = strconv.Itoa(n)
+			}
+			fmt.Fprintf(&b, " %*s", width, cell)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	minuteWidth := len(strconv.Itoa(mostMinute))
+	for minute := 0; minute < 60; minute++ {
+		n := h.Minute(minute)
+		bar := 0
+		if mostMinute > 0 {
+			bar = (n*heatmapBar + mostMinute - 1) / mostMinute
+		}
+		line := fmt.Sprintf(":%02d %*d %s", minute, minuteWidth, n, strings.Repeat("#", bar))
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+/*
+WriteCSV writes the heatmap as CSV records of weekday, hour, minute and count,
+a record for each minute with activations
+*/
+func (h *Heatmap) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"weekday", "hour", "minute", "count"}); err != nil {
+		return err
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		for hour := 0; hour < 24; hour++ {
+			for minute, n := range h.Counts[weekday][hour] {
+				if n == 0 {
+					continue
+				}
+				record := []string{weekday.String(), strconv.Itoa(hour), strconv.Itoa(minute), strconv.Itoa(n)}
+				if err := out.Write(record); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// The layout of WriteSVG, a cell of the grid for each weekday and hour above a bar
+// for each minute of the hour
+const (
+	svgLabel     = 40
+	svgCell      = 24
+	svgBar       = 9
+	svgBarHeight = 100
+	svgGap       = 30
+)
+
+/*
+WriteSVG writes the heatmap as an SVG image, a grid of the activations by weekday and
+hour shaded by their count above a bar chart of the activations by minute of the hour
+*/
+func (h *Heatmap) WriteSVG(w io.Writer) error {
+	most, mostMinute := h.most()
+	width := svgLabel + 24*svgCell
+	gridTop := 20
+	chartTop := gridTop + 7*svgCell + svgGap
+	height := chartTop + svgBarHeight + 20
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %[1]d %[2]d" font-family="sans-serif" font-size="10">`+"\n", width, height)
+	for hour := 0; hour < 24; hour++ {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">%02d</text>`+"\n", svgLabel+hour*svgCell+svgCell/2, gridTop-6, hour)
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		y := gridTop + int(weekday)*svgCell
+		fmt.Fprintf(&b, `<text x="0" y="%d">%s</text>`+"\n", y+svgCell/2+4, weekday.String()[:3])
+		for hour := 0; hour < 24; hour++ {
+			n := h.Hour(weekday, hour)
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="#ffffff"><title>%s %02d:00 %d</title></rect>`+"\n",
+				svgLabel+hour*svgCell, y, svgCell, svgCell, shade(n, most), weekday, hour, n)
+		}
+	}
+
+	for minute := 0; minute < 60; minute++ {
+		n := h.Minute(minute)
+		barHeight := 0
+		if mostMinute > 0 {
+			barHeight = n * svgBarHeight / mostMinute
+		}
+		x := svgLabel + minute*svgBar
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="#2166ac"><title>:%02d %d</title></rect>`+"\n",
+			x, chartTop+svgBarHeight-barHeight, svgBar-1, barHeight, minute, n)
+		if minute%15 == 0 {
+			fmt.Fprintf(&b, `<text x="%d" y="%d">:%02d</text>`+"\n", x, chartTop+svgBarHeight+14, minute)
+		}
+	}
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// most returns the greatest count of a weekday and hour, and of a minute of the hour
+func (h *Heatmap) most() (hour, minute int) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		for i := 0; i < 24; i++ {
+			if n := h.Hour(weekday, i); n > hour {
+				hour = n
+			}
+		}
+	}
+	for i := 0; i < 60; i++ {
+		if n := h.Minute(i); n > minute {
+			minute = n
+		}
+	}
+	return hour, minute
+}
+
+// shade returns the colour of a cell from white, for none, to blue for the most
+func shade(n, most int) string {
+	if n == 0 || most == 0 {
+		return "#ffffff"
+	}
+	// Blend from a light blue towards #2166ac
+	scale := func(light, dark int) int {
+		return light + (dark-light)*n/most
+	}
+	return fmt.Sprintf("#%02x%02x%02x", scale(0xd1, 0x21), scale(0xe5, 0x66), scale(0xf0, 0xac))
+}
@@ -0,0 +1,123 @@
+package cron
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHeatmap(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(t, err) {
+		return
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	heatmap, err := NewHeatmap([]*Cron{
+		MustParse("*/15 9-17 * * 1-5"),
+		MustParse("0 * * * *"),
+		MustParse("30 9 * * 1", WithLocation(newYork)),
+		MustParse("*/20 0 10 * * *", WithSeconds()),
+	}, from, from.AddDate(0, 0, 7), time.UTC)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// 9am on Monday has four quarters, the hourly schedule and three activations a minute at 10:00
+	assert.Equal(t, 5, heatmap.Hour(time.Monday, 9))
+	assert.Equal(t, 8, heatmap.Hour(time.Monday, 10))
+	assert.Equal(t, 1, heatmap.Hour(time.Sunday, 9))
+	// 9:30 in New York is 14:30 in UTC in January
+	assert.Equal(t, 2, heatmap.Counts[time.Monday][14][30])
+	assert.Equal(t, 6, heatmap.Hour(time.Monday, 14))
+
+	assert.Equal(t, 5*9+7*24+7*3, heatmap.Minute(0))
+	assert.Equal(t, 5*9+1, heatmap.Minute(30))
+	assert.Equal(t, 0, heatmap.Minute(1))
+}
+
+func TestNewHeatmap_Location(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if !assert.NoError(t, err) {
+		return
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	heatmap, err := NewHeatmap([]*Cron{MustParse("0 23 * * 0")}, from, from.AddDate(0, 0, 7), tokyo)
+	if assert.NoError(t, err) {
+		// 23:00 on Sunday in UTC is 8:00 on Monday in Tokyo
+		assert.Equal(t, 1, heatmap.Hour(time.Monday, 8))
+		assert.Equal(t, 0, heatmap.Hour(time.Sunday, 23))
+	}
+}
+
+func TestHeatmap_WriteText(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	heatmap, err := NewHeatmap([]*Cron{MustParse("0,30 9 * * 1"), MustParse("0 9 * * 1")}, from, from.AddDate(0, 0, 7), time.UTC)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var b strings.Builder
+	if !assert.NoError(t, heatmap.WriteText(&b)) {
+		return
+	}
+	lines := strings.Split(b.String(), "\n")
+	assert.Equal(t, "    00 01 02 03 04 05 06 07 08 09 10 11 12 13 14 15 16 17 18 19 20 21 22 23", lines[0])
+	assert.Equal(t, "Sun  .  .  .  .  .  .  .  .  .  .  .  .  .  .  .  .  .  .  .  .  .  .  .  .", lines[1])
+	assert.Equal(t, "Mon  .  .  .  .  .  .  .  .  .  3  .  .  .  .  .  .  .  .  .  .  .  .  .  .", lines[2])
+	assert.Equal(t, "", lines[8])
+	assert.Equal(t, ":00 2 "+strings.Repeat("#", 50), lines[9])
+	assert.Equal(t, ":01 0", lines[10])
+	assert.Equal(t, ":30 1 "+strings.Repeat("#", 25), lines[39])
+	assert.Len(t, lines, 70)
+}
+
+func TestHeatmap_WriteCSV(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	heatmap, err := NewHeatmap([]*Cron{MustParse("0,30 9 * * 1"), MustParse("0 9 * * 1,3")}, from, from.AddDate(0, 0, 7), time.UTC)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var b strings.Builder
+	if assert.NoError(t, heatmap.WriteCSV(&b)) {
+		assert.Equal(t, "weekday,hour,minute,count\nMonday,9,0,2\nMonday,9,30,1\nWednesday,9,0,1\n", b.String())
+	}
+}
+
+func TestHeatmap_WriteSVG(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	heatmap, err := NewHeatmap([]*Cron{MustParse("*/15 9-17 * * 1-5")}, from, from.AddDate(0, 0, 7), time.UTC)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var b bytes.Buffer
+	if !assert.NoError(t, heatmap.WriteSVG(&b)) {
+		return
+	}
+
+	var svg struct {
+		XMLName xml.Name `xml:"svg"`
+		Rects   []struct {
+			Fill  string `xml:"fill,attr"`
+			Title string `xml:"title"`
+		} `xml:"rect"`
+	}
+	if !assert.NoError(t, xml.Unmarshal(b.Bytes(), &svg)) {
+		return
+	}
+	assert.Len(t, svg.Rects, 7*24+60)
+	assert.Equal(t, [2]string{"#ffffff", "Sunday 09:00 0"}, [2]string{svg.Rects[9].Fill, svg.Rects[9].Title})
+	assert.Equal(t, [2]string{"#2166ac", "Monday 09:00 4"}, [2]string{svg.Rects[24+9].Fill, svg.Rects[24+9].Title})
+	assert.Equal(t, ":15 45", svg.Rects[7*24+15].Title)
+}
+
+func TestNewHeatmap_Error(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := NewHeatmap([]*Cron{MustParse("* * * * *")}, from, from, time.UTC)
+	assert.EqualError(t, err, "heatmap window must end after it starts")
+	_, err = NewHeatmap([]*Cron{MustParse("* * * * *")}, from, from.Add(time.Hour), nil)
+	assert.EqualError(t, err, "heatmap requires a location")
+	_, err = NewHeatmap([]*Cron{nil}, from, from.Add(time.Hour), time.UTC)
+	assert.EqualError(t, err, "schedule 0 is nil")
+}
@@ -0,0 +1,266 @@
+package ics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frisbm/cron"
+)
+
+/*
+ReadCalendar reads the VEVENTs of an iCalendar file, such as a bank holiday feed,
+into a cron.Calendar that excludes the days they take place on, to be given to
+cron.WithCalendar. An event lasts from the date of its DTSTART until its DTEND
+or DURATION, recurs by its RRULE, less its EXDATEs, and cancelled events are
+left out. The dates are taken as written, whichever time zone they are in.
+*/
+func ReadCalendar(r io.Reader) (cron.Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &holidays{dates: map[civilDate]bool{}}
+	var event *holiday
+	// nested counts the components open within the VEVENT, i.e. its VALARMs
+	nested := 0
+	for _, line := range lines {
+		name, value, ok := property(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && event == nil:
+			if strings.EqualFold(value, "VEVENT") {
+				event = &holiday{exdates: map[civilDate]bool{}}
+			}
+			continue
+		case name == "BEGIN":
+			nested++
+			continue
+		case event == nil:
+			continue
+		case name == "END" && nested > 0:
+			nested--
+			continue
+		case nested > 0:
+			continue
+		case name == "END":
+			if err := calendar.add(event); err != nil {
+				return nil, err
+			}
+			event = nil
+			continue
+		}
+
+		switch name {
+		case "DTSTART":
+			event.start, err = parseDate(value)
+		case "DTEND":
+			// The day of a DTEND is part of the event, unless it ends at its midnight
+			if event.end, err = parseDate(value); err == nil && len(value) > len("20060102") && !strings.HasPrefix(value[8:], "T000000") {
+				event.end = event.end.AddDate(0, 0, 1)
+			}
+		case "DURATION":
+			event.duration, err = parseDuration(value)
+		case "RRULE":
+			event.rule = value
+		case "EXDATE":
+			for _, exdate := range strings.Split(value, ",") {
+				var day time.Time
+				if day, err = parseDate(exdate); err != nil {
+					break
+				}
+				event.exdates[dateOf(day)] = true
+			}
+		case "STATUS":
+			event.cancelled = strings.EqualFold(value, "CANCELLED")
+		case "SUMMARY":
+			event.summary = value
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s of %q: %w", name, event.summary, err)
+		}
+	}
+	if event != nil {
+		return nil, errors.New("the last VEVENT has no END")
+	}
+	return calendar, nil
+}
+
+// civilDate is a day of the calendar, whatever the time zone
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) civilDate {
+	year, month, day := t.Date()
+	return civilDate{year: year, month: month, day: day}
+}
+
+// holiday is a VEVENT as it is read, its dates at midnight UTC
+type holiday struct {
+	summary   string
+	start     time.Time
+	end       time.Time
+	duration  time.Duration
+	rule      string
+	exdates   map[civilDate]bool
+	cancelled bool
+}
+
+// recurring is a holiday that recurs by an RRULE for days days
+type recurring struct {
+	start   time.Time
+	rule    *cron.Cron
+	days    int
+	exdates map[civilDate]bool
+}
+
+// holidays is the Calendar read from the VEVENTs
+type holidays struct {
+	dates     map[civilDate]bool
+	recurring []recurring
+}
+
+// add adds the days of an event to the calendar
+func (h *holidays) add(event *holiday) error {
+	if event.cancelled {
+		return nil
+	}
+	if event.start.IsZero() {
+		return fmt.Errorf("%q has no DTSTART", event.summary)
+	}
+
+	days := 1
+	switch {
+	case !event.end.IsZero():
+		days = int(event.end.Sub(event.start).Hours() / 24)
+	case event.duration > 0:
+		days = int((event.duration + 24*time.Hour - 1) / (24 * time.Hour))
+	}
+	if days < 1 {
+		days = 1
+	}
+
+	if event.rule == "" {
+		for i := 0; i < days; i++ {
+			if day := dateOf(event.start.AddDate(0, 0, i)); !event.exdates[day] {
+				h.dates[day] = true
+			}
+		}
+		return nil
+	}
+	rule, err := cron.ParseRRule(event.rule, event.start)
+	if err != nil {
+		return fmt.Errorf("RRULE of %q: %w", event.summary, err)
+	}
+	h.recurring = append(h.recurring, recurring{start: event.start, rule: rule, days: days, exdates: event.exdates})
+	return nil
+}
+
+func (h *holidays) Excludes(t time.Time) bool {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if h.dates[dateOf(date)] {
+		return true
+	}
+	for _, event := range h.recurring {
+		// The occurrences that started on the days before and still last
+		for i := 0; i < event.days; i++ {
+			start := date.AddDate(0, 0, -i)
+			if start.Before(event.start) || event.exdates[dateOf(start)] {
+				continue
+			}
+			if next := event.rule.NextFrom(start.Add(-time.Nanosecond)); !next.IsZero() && next.Before(start.AddDate(0, 0, 1)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// unfold reads the content lines of an iCalendar file, joining folded lines.
+// Lines may be of any length, unlike with a bufio.Scanner
+func unfold(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for rest := string(data); rest != ""; {
+		end := strings.IndexByte(rest, '\n') + 1
+		if end == 0 {
+			end = len(rest)
+		}
+		line := strings.TrimRight(rest[:end], "\r\n")
+		rest = rest[end:]
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// property splits a content line into its upper case name and its value, leaving
+// out the parameters
+func property(line string) (string, string, bool) {
+	// The value starts at the first colon that is not within a quoted parameter
+	quoted := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '"':
+			quoted = !quoted
+		case line[i] == ':' && !quoted:
+			name, _, _ := strings.Cut(line[:i], ";")
+			return strings.ToUpper(name), line[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// parseDate reads a DATE or DATE-TIME value as its date, at midnight UTC
+func parseDate(value string) (time.Time, error) {
+	if len(value) < len("20060102") || (len(value) > len("20060102") && value[8] != 'T') {
+		return time.Time{}, fmt.Errorf("%q is not a date", value)
+	}
+	return time.Parse("20060102", value[:len("20060102")])
+}
+
+// parseDuration reads a DURATION, which is positive for an event
+func parseDuration(value string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(strings.TrimPrefix(value, "+"), "P")
+	if !ok {
+		return 0, fmt.Errorf("%q is not a duration", value)
+	}
+	var d time.Duration
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour, 'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+	for rest != "" {
+		if rest[0] == 'T' {
+			rest = rest[1:]
+			continue
+		}
+		end := 0
+		for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+			end++
+		}
+		n, err := strconv.Atoi(rest[:end])
+		if err != nil || end == len(rest) || units[rest[end]] == 0 {
+			return 0, fmt.Errorf("%q is not a duration", value)
+		}
+		d += time.Duration(n) * units[rest[end]]
+		rest = rest[end+1:]
+	}
+	return d, nil
+}
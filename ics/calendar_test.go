@@ -0,0 +1,130 @@
+package ics
+
+import (
+	"github.com/frisbm/cron"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const bankHolidays = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Example//Holidays//EN
+BEGIN:VEVENT
+UID:1
+DTSTART;VALUE=DATE:20240101
+DTEND;VALUE=DATE:20240102
+SUMMARY:New Year's Day
+END:VEVENT
+BEGIN:VEVENT
+UID:2
+DTSTART;VALUE=DATE:20231225
+DTEND;VALUE=DATE:20231227
+RRULE:FREQ=YEARLY
+EXDATE;VALUE=DATE:20261225
+SUMMARY:Christmas Day and
+  Boxing Day
+BEGIN:VALARM
+ACTION:DISPLAY
+TRIGGER:-P1D
+DTSTART:19700101T000000
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+UID:3
+DTSTART;VALUE=DATE:20230529
+RRULE:FREQ=YEARLY;BYMONTH=5;BYDAY=-1MO
+SUMMARY:Spring bank holiday
+END:VEVENT
+BEGIN:VEVENT
+UID:4
+DTSTART;TZID="Europe/London":20240812T090000
+DURATION:P1W
+SUMMARY:Summer freeze
+END:VEVENT
+BEGIN:VEVENT
+UID:5
+DTSTART;VALUE=DATE:20240506
+STATUS:CANCELLED
+SUMMARY:Early May bank holiday
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestReadCalendar(t *testing.T) {
+	calendar, err := ReadCalendar(strings.NewReader(strings.ReplaceAll(bankHolidays, "\n", "\r\n")))
+	if !assert.NoError(t, err) {
+		return
+	}
+	tests := []struct {
+		date time.Time
+		want bool
+	}{
+		{date: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2022, 12, 25, 9, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2023, 12, 25, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2025, 12, 26, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2025, 12, 27, 9, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2026, 12, 25, 9, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2026, 12, 26, 9, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2024, 5, 27, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2025, 5, 26, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2025, 5, 19, 9, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2024, 8, 12, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2024, 8, 18, 9, 0, 0, 0, time.UTC), want: true},
+		{date: time.Date(2024, 8, 19, 9, 0, 0, 0, time.UTC), want: false},
+		{date: time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date.Format(time.DateOnly), func(t *testing.T) {
+			assert.Equal(t, tt.want, calendar.Excludes(tt.date))
+		})
+	}
+}
+
+func TestReadCalendar_WithCalendar(t *testing.T) {
+	calendar, err := ReadCalendar(strings.NewReader(bankHolidays))
+	if !assert.NoError(t, err) {
+		return
+	}
+	payments := cron.MustParse("0 6 * * 1-5", cron.WithCalendar(calendar))
+	next := payments.NextFrom(time.Date(2023, 12, 22, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2023, 12, 27, 6, 0, 0, 0, time.UTC), next)
+}
+
+func TestReadCalendar_LongLine(t *testing.T) {
+	// Lines are not limited to the 64KiB of a bufio.Scanner
+	description := "DESCRIPTION:" + strings.Repeat("x", 70*1024) + "\n " + strings.Repeat("y", 70*1024) + "\n"
+	calendar, err := ReadCalendar(strings.NewReader(strings.Replace(bankHolidays, "SUMMARY:New Year's Day\n", "SUMMARY:New Year's Day\n"+description, 1)))
+	if assert.NoError(t, err) {
+		assert.True(t, calendar.Excludes(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)))
+	}
+}
+
+func TestReadCalendar_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+		want string
+	}{
+		{name: "no DTSTART", ics: "BEGIN:VEVENT\nSUMMARY:Holiday\nEND:VEVENT\n", want: `"Holiday" has no DTSTART`},
+		{name: "bad date", ics: "BEGIN:VEVENT\nSUMMARY:Holiday\nDTSTART:2024-01-01\nEND:VEVENT\n", want: `DTSTART of "Holiday": "2024-01-01" is not a date`},
+		{name: "bad duration", ics: "BEGIN:VEVENT\nSUMMARY:Holiday\nDURATION:1D\nEND:VEVENT\n", want: `DURATION of "Holiday": "1D" is not a duration`},
+		{
+			name: "unsupported rule",
+			ics:  "BEGIN:VEVENT\nSUMMARY:Holiday\nDTSTART:20240101\nRRULE:FREQ=YEARLY;COUNT=3\nEND:VEVENT\n",
+			want: `RRULE of "Holiday"`,
+		},
+		{name: "unterminated", ics: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240101\n", want: "the last VEVENT has no END"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadCalendar(strings.NewReader(tt.ics))
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.want)
+			}
+		})
+	}
+}
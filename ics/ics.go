@@ -0,0 +1,417 @@
+/*
+Package ics writes the activations of schedules as an iCalendar (RFC 5545)
+VCALENDAR, so that when a job runs can be subscribed to from a calendar
+application such as Outlook or Google Calendar.
+
+A schedule that an RRULE describes exactly within the window is written as a
+recurring event, any other schedule as an event per activation. The UID of an
+event only depends on the name of its schedule and what it recurs on, or when
+it activates, so a calendar written again for another window updates the events
+it already has rather than adding them twice.
+
+ReadCalendar goes the other way, reading a holiday feed into a cron.Calendar of
+the days a schedule should skip.
+*/
+package ics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frisbm/cron"
+)
+
+// maxActivations bounds the events written for a schedule that does not recur as an RRULE
+const maxActivations = 10000
+
+const (
+	utcFormat   = "20060102T150405Z"
+	localFormat = "20060102T150405"
+)
+
+/*
+Event is a named schedule to write the activations of
+*/
+type Event struct {
+	// Name is the summary of the events, and part of their UIDs
+	Name string
+	// Schedule is when the events happen, in the location of its activations
+	Schedule cron.Schedule
+	// Duration is how long each event lasts, an event without one takes no time
+	Duration time.Duration
+	// Description is written as the description of the events, i.e. the command
+	Description string
+}
+
+/*
+Option configures how Write writes a calendar
+*/
+type Option func(c *config)
+
+type config struct {
+	productID string
+	name      string
+	clock     cron.Clock
+}
+
+/*
+WithProductID will set the PRODID of the calendar, the product that wrote it
+*/
+func WithProductID(id string) Option {
+	return func(c *config) {
+		c.productID = id
+	}
+}
+
+/*
+WithName will set the name calendar applications show for the calendar
+*/
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+/*
+WithClock will set the clock the time the calendar was written is read
+from, by default the cron.SystemClock is used
+*/
+func WithClock(clock cron.Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+/*
+Write writes a VCALENDAR of the activations of the events from from until to,
+including from but not to. Each location the activations are in is defined by
+a VTIMEZONE covering the window, activations in UTC are written in UTC.
+*/
+func Write(w io.Writer, from, to time.Time, events []Event, opts ...Option) error {
+	conf := config{productID: "-//frisbm//cron//EN", clock: cron.SystemClock}
+	for _, opt := range opts {
+		opt(&conf)
+	}
+	if !from.Before(to) {
+		return errors.New("the window must end after it starts")
+	}
+
+	b := &builder{stamp: conf.clock.Now().UTC().Format(utcFormat), zones: map[string]*time.Location{}}
+	for _, event := range events {
+		if event.Name == "" || event.Schedule == nil {
+			return errors.New("every event needs a name and a schedule")
+		}
+		if err := b.addEvent(event, from, to); err != nil {
+			return err
+		}
+	}
+
+	cal := &writer{w: w}
+	cal.line("BEGIN:VCALENDAR")
+	cal.line("VERSION:2.0")
+	cal.line("PRODID:" + escape(conf.productID))
+	cal.line("CALSCALE:GREGORIAN")
+	cal.line("METHOD:PUBLISH")
+	if conf.name != "" {
+		cal.line("X-WR-CALNAME:" + escape(conf.name))
+	}
+	names := make([]string, 0, len(b.zones))
+	for name := range b.zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeTimezone(cal, name, b.zones[name], from, to)
+	}
+	for _, lines := range b.events {
+		for _, line := range lines {
+			cal.line(line)
+		}
+	}
+	cal.line("END:VCALENDAR")
+	return cal.err
+}
+
+// builder collects the VEVENTs of a calendar and the locations they are in
+type builder struct {
+	stamp  string
+	zones  map[string]*time.Location
+	events [][]string
+}
+
+// addEvent adds the VEVENTs of an event, as recurring events where RRULEs
+// describe the schedule exactly within the window and singly otherwise
+func (b *builder) addEvent(event Event, from, to time.Time) error {
+	if schedule, ok := event.Schedule.(*cron.Cron); ok {
+		// Narrow the window to the bounds of the schedule, activations are whole seconds
+		if notBefore := schedule.NotBefore(); notBefore.After(from) {
+			from = notBefore
+		}
+		if notAfter := schedule.NotAfter(); !notAfter.IsZero() && notAfter.Before(to) {
+			to = notAfter.Truncate(time.Second).Add(time.Second)
+		}
+	}
+	first := event.Schedule.NextFrom(from.Add(-time.Nanosecond))
+	if first.IsZero() || !first.Before(to) {
+		return nil
+	}
+	if rules, ok := recurrence(event.Schedule, first, from, to); ok {
+		for _, rule := range rules {
+			b.add(event, rule.start, rule.rule+";UNTIL="+to.Add(-time.Second).UTC().Format(utcFormat), rule.rule)
+		}
+		return nil
+	}
+
+	count := 0
+	for next := first; !next.IsZero() && next.Before(to); next = event.Schedule.NextFrom(next) {
+		if count++; count > maxActivations {
+			return fmt.Errorf("%s activates more than %d times in the window", event.Name, maxActivations)
+		}
+		b.add(event, next, "", next.UTC().Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
+// add adds a VEVENT starting at start, recurring by rule when it is set,
+// key tells it apart from the other VEVENTs of the event for its UID
+func (b *builder) add(event Event, start time.Time, rule, key string) {
+	uid := sha256.Sum256([]byte(event.Name + "\x00" + key))
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + hex.EncodeToString(uid[:16]),
+		"DTSTAMP:" + b.stamp,
+		"DTSTART" + b.dateTime(start),
+	}
+	if event.Duration > 0 {
+		lines = append(lines, "DURATION:"+duration(event.Duration))
+	}
+	if rule != "" {
+		lines = append(lines, "RRULE:"+rule)
+	}
+	lines = append(lines, "SUMMARY:"+escape(event.Name))
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escape(event.Description))
+	}
+	b.events = append(b.events, append(lines, "END:VEVENT"))
+}
+
+// dateTime writes the value of a DTSTART, with the TZID of its location unless it is
+// UTC, or a wall clock time that daylight saving repeats and a TZID cannot tell apart
+func (b *builder) dateTime(t time.Time) string {
+	loc := t.Location()
+	if loc == time.UTC || ambiguous(t) {
+		return ":" + t.UTC().Format(utcFormat)
+	}
+	b.zones[loc.String()] = loc
+	return ";TZID=" + loc.String() + ":" + t.Format(localFormat)
+}
+
+// ambiguous reports whether the wall clock time of t happens twice, because the
+// clocks go back at the end of its zone or went back at the start of it
+func ambiguous(t time.Time) bool {
+	start, end := t.ZoneBounds()
+	_, offset := t.Zone()
+	if !start.IsZero() {
+		_, before := start.Add(-time.Second).Zone()
+		if before > offset && t.Sub(start) < time.Duration(before-offset)*time.Second {
+			return true
+		}
+	}
+	if !end.IsZero() {
+		_, after := end.Zone()
+		if after < offset && end.Sub(t) <= time.Duration(offset-after)*time.Second {
+			return true
+		}
+	}
+	return false
+}
+
+// rrule is a rule of a recurring event and the first activation it starts from
+type rrule struct {
+	rule  string
+	start time.Time
+}
+
+// recurrence returns the RRULEs of a schedule, if they describe it exactly within
+// the window. A Rate is written in UTC so that its interval is kept across
+// daylight saving, the rules of a Cron are checked against the daylight saving
+// transitions of the window, as an RRULE moves a time skipped by a transition
+// and takes a repeated time once, where a Cron skips it and takes it twice.
+func recurrence(schedule cron.Schedule, first, from, to time.Time) ([]rrule, bool) {
+	switch schedule := schedule.(type) {
+	case *cron.Rate:
+		interval := schedule.Interval()
+		if interval%time.Second != 0 || first.Nanosecond() != 0 {
+			return nil, false
+		}
+		rule := "FREQ=SECONDLY;INTERVAL=" + strconv.FormatInt(int64(interval/time.Second), 10)
+		if interval%time.Minute == 0 {
+			rule = "FREQ=MINUTELY;INTERVAL=" + strconv.FormatInt(int64(interval/time.Minute), 10)
+		}
+		return []rrule{{rule: rule, start: first.UTC()}}, true
+	case *cron.Cron:
+		rules, err := schedule.RRule()
+		if err != nil {
+			return nil, false
+		}
+		loc := first.Location()
+		var recurring []rrule
+		for _, rule := range rules {
+			// The UNTIL of a NotAfter bound is replaced by the end of the window
+			rule, _, _ = strings.Cut(rule, ";UNTIL=")
+			parsed, err := cron.ParseRRule(rule, first.Truncate(time.Minute))
+			if err != nil {
+				return nil, false
+			}
+			start := parsed.NextFrom(from.Add(-time.Nanosecond))
+			if start.IsZero() || !start.Before(to) {
+				continue
+			}
+			if ambiguous(start) || touchesTransition(rule, start, from, to) {
+				return nil, false
+			}
+			recurring = append(recurring, rrule{rule: rule, start: start.In(loc)})
+		}
+		return recurring, true
+	}
+	return nil, false
+}
+
+// touchesTransition reports whether the rule falls on a time skipped or repeated by a
+// change of UTC offset within the window. Those wall clock times are the ones just
+// after the transition in the lesser of the two offsets.
+func touchesTransition(rule string, start, from, to time.Time) bool {
+	for t := from.In(start.Location()); t.Before(to); {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || !end.Before(to) {
+			return false
+		}
+		_, before := end.Add(-time.Second).Zone()
+		_, after := end.Zone()
+		if before != after {
+			offset, delta := before, after-before
+			if after < before {
+				offset, delta = after, before-after
+			}
+			wall := time.FixedZone("", offset)
+			parsed, err := cron.ParseRRule(rule, start.In(wall))
+			if err != nil {
+				return true
+			}
+			next := parsed.NextFrom(end.In(wall).Add(-time.Nanosecond))
+			if !next.IsZero() && next.Before(end.Add(time.Duration(delta)*time.Second)) {
+				return true
+			}
+		}
+		t = end
+	}
+	return false
+}
+
+// writeTimezone writes a VTIMEZONE for the location, with an observance for
+// each of its zones from the one in effect at from until the last before to
+func writeTimezone(cal *writer, name string, loc *time.Location, from, to time.Time) {
+	cal.line("BEGIN:VTIMEZONE")
+	cal.line("TZID:" + name)
+	for t := from.In(loc); ; {
+		start, end := t.ZoneBounds()
+		abbreviation, offset := t.Zone()
+		offsetFrom := offset
+		onset := "19700101T000000"
+		if !start.IsZero() {
+			_, offsetFrom = start.Add(-time.Second).Zone()
+			onset = start.In(time.FixedZone("", offsetFrom)).Format(localFormat)
+		}
+
+		kind := "STANDARD"
+		if t.IsDST() {
+			kind = "DAYLIGHT"
+		}
+		cal.line("BEGIN:" + kind)
+		cal.line("DTSTART:" + onset)
+		cal.line("TZOFFSETFROM:" + utcOffset(offsetFrom))
+		cal.line("TZOFFSETTO:" + utcOffset(offset))
+		cal.line("TZNAME:" + escape(abbreviation))
+		cal.line("END:" + kind)
+
+		if end.IsZero() || !end.Before(to) {
+			break
+		}
+		t = end
+	}
+	cal.line("END:VTIMEZONE")
+}
+
+// utcOffset writes an offset in seconds east of UTC as +hhmm, or +hhmmss
+func utcOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		s += fmt.Sprintf("%02d", offset%60)
+	}
+	return s
+}
+
+// duration writes a positive duration as an iCalendar DURATION, to the second
+func duration(d time.Duration) string {
+	seconds := int64((d + time.Second - 1) / time.Second)
+	s := "P"
+	if days := seconds / 86400; days > 0 {
+		s += strconv.FormatInt(days, 10) + "D"
+	}
+	if seconds%86400 == 0 {
+		return s
+	}
+	s += "T"
+	if hours := seconds / 3600 % 24; hours > 0 {
+		s += strconv.FormatInt(hours, 10) + "H"
+	}
+	if minutes := seconds / 60 % 60; minutes > 0 {
+		s += strconv.FormatInt(minutes, 10) + "M"
+	}
+	if seconds%60 > 0 {
+		s += strconv.FormatInt(seconds%60, 10) + "S"
+	}
+	return s
+}
+
+// escape escapes the characters with a meaning in a TEXT value
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(text)
+}
+
+// writer writes content lines ended with CRLF, folded to 75 octets, and keeps
+// the first error so that the lines can be written without checking each one
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+func (w *writer) line(line string) {
+	if w.err != nil {
+		return
+	}
+	var b strings.Builder
+	// Fold at 75 octets without splitting a UTF-8 sequence, the continuation
+	// lines begin with a space that counts towards them
+	for limit := 75; len(line) > limit; limit = 74 {
+		cut := limit
+		for line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+	}
+	b.WriteString(line + "\r\n")
+	_, w.err = io.WriteString(w.w, b.String())
+}
@@ -0,0 +1,222 @@
+package ics
+
+import (
+	"bytes"
+	"errors"
+	"github.com/frisbm/cron"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+var stamp = cron.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+func TestWrite(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	err := Write(&buf, from, from.AddDate(0, 1, 0), []Event{{
+		Name:        "backup",
+		Schedule:    cron.MustParse("0 9 * * 1-5"),
+		Duration:    90 * time.Minute,
+		Description: "pg_dump prod, gzip",
+	}}, WithClock(stamp), WithName("Jobs"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//frisbm//cron//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Jobs",
+		"BEGIN:VEVENT",
+		"UID:" + uid("backup", "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0"),
+		"DTSTAMP:20240101T120000Z",
+		"DTSTART:20240101T090000Z",
+		"DURATION:PT1H30M",
+		// Lines longer than 75 octets are folded
+		"RRULE:FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0;UNTIL=20240131T23",
+		" 5959Z",
+		"SUMMARY:backup",
+		`DESCRIPTION:pg_dump prod\, gzip`,
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"), buf.String())
+}
+
+func TestWrite_TimeZone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(t, err) {
+		return
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, newYork)
+	var buf bytes.Buffer
+	err = Write(&buf, from, from.AddDate(0, 6, 0), []Event{{
+		Name:     "report",
+		Schedule: cron.MustParse("0 9 * * *", cron.WithLocation(newYork)),
+	}}, WithClock(stamp))
+	if !assert.NoError(t, err) {
+		return
+	}
+	out := buf.String()
+	assert.Contains(t, out, strings.Join([]string{
+		"BEGIN:VTIMEZONE",
+		"TZID:America/New_York",
+		"BEGIN:STANDARD",
+		"DTSTART:20231105T020000",
+		"TZOFFSETFROM:-0400",
+		"TZOFFSETTO:-0500",
+		"TZNAME:EST",
+		"END:STANDARD",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20240310T020000",
+		"TZOFFSETFROM:-0500",
+		"TZOFFSETTO:-0400",
+		"TZNAME:EDT",
+		"END:DAYLIGHT",
+		"END:VTIMEZONE",
+	}, "\r\n"))
+	assert.Contains(t, out, "DTSTART;TZID=America/New_York:20240101T090000\r\n")
+	assert.Contains(t, out, "RRULE:FREQ=DAILY;BYHOUR=9;BYMINUTE=0;UNTIL=20240701T035959Z\r\n")
+}
+
+// TestWrite_DaylightSaving checks that a schedule an RRULE would not follow
+// across a daylight saving transition is written as single events
+func TestWrite_DaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(t, err) {
+		return
+	}
+	from := time.Date(2024, 11, 2, 0, 0, 0, 0, newYork)
+	var buf bytes.Buffer
+	err = Write(&buf, from, from.AddDate(0, 0, 3), []Event{{
+		Name:     "rotate",
+		Schedule: cron.MustParse("30 1 * * *", cron.WithLocation(newYork)),
+	}}, WithClock(stamp))
+	if !assert.NoError(t, err) {
+		return
+	}
+	out := buf.String()
+	assert.NotContains(t, out, "RRULE")
+	// 1:30am happens twice on the 3rd, in EDT and then in EST
+	for _, start := range []string{
+		"DTSTART;TZID=America/New_York:20241102T013000",
+		"DTSTART:20241103T053000Z",
+		"DTSTART:20241103T063000Z",
+		"DTSTART;TZID=America/New_York:20241104T013000",
+	} {
+		assert.Contains(t, out, start+"\r\n")
+	}
+	assert.Equal(t, 4, strings.Count(out, "BEGIN:VEVENT"))
+}
+
+func TestWrite_Rate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 15, 0, 0, time.UTC)
+	rate, err := cron.NewRate(90*time.Minute, start, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var buf bytes.Buffer
+	err = Write(&buf, start.Add(-time.Hour), start.AddDate(0, 0, 1), []Event{{Name: "sync", Schedule: rate}}, WithClock(stamp))
+	if assert.NoError(t, err) {
+		assert.Contains(t, buf.String(), "DTSTART:20240101T001500Z\r\nRRULE:FREQ=MINUTELY;INTERVAL=90;UNTIL=20240102T001459Z\r\n")
+	}
+}
+
+// TestWrite_Bounds checks that the events keep within the NotBefore and NotAfter
+// bounds of a schedule
+func TestWrite_Bounds(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule := cron.MustParse("0 9 * * *",
+		cron.WithNotBefore(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+		cron.WithNotAfter(time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC)))
+	var buf bytes.Buffer
+	err := Write(&buf, from, from.AddDate(0, 1, 0), []Event{{Name: "campaign", Schedule: schedule}}, WithClock(stamp))
+	if assert.NoError(t, err) {
+		assert.Contains(t, buf.String(), "DTSTART:20240110T090000Z\r\nRRULE:FREQ=DAILY;BYHOUR=9;BYMINUTE=0;UNTIL=20240120T090000Z\r\n")
+	}
+}
+
+// TestWrite_StableUIDs checks that calendars written for overlapping windows
+// give an activation the same UID
+func TestWrite_StableUIDs(t *testing.T) {
+	events := []Event{{Name: "cleanup", Schedule: cron.MustParse("0 0 15W * ?")}}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var first, second bytes.Buffer
+	assert.NoError(t, Write(&first, from, from.AddDate(0, 3, 0), events, WithClock(stamp)))
+	assert.NoError(t, Write(&second, from.AddDate(0, 1, 0), from.AddDate(0, 4, 0), events, WithClock(stamp)))
+
+	want := "UID:" + uid("cleanup", "2024-02-15T00:00:00Z")
+	assert.Contains(t, first.String(), want)
+	assert.Contains(t, second.String(), want)
+	assert.Equal(t, 3, strings.Count(first.String(), "BEGIN:VEVENT"))
+}
+
+func TestWrite_Folding(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	err := Write(&buf, from, from.AddDate(0, 0, 1), []Event{{
+		Name:        "nightly",
+		Schedule:    cron.MustParse("0 0 * * *"),
+		Description: strings.Repeat("é", 60) + "\nrm -rf /tmp/cache; echo done",
+	}}, WithClock(stamp))
+	if !assert.NoError(t, err) {
+		return
+	}
+	var unfolded strings.Builder
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+		if strings.HasPrefix(line, " ") && i > 0 {
+			unfolded.WriteString(line[1:])
+		} else {
+			unfolded.WriteString("\n" + line)
+		}
+	}
+	assert.Contains(t, unfolded.String(), "\nDESCRIPTION:"+strings.Repeat("é", 60)+`\nrm -rf /tmp/cache\; echo done`+"\n")
+}
+
+func TestWrite_Errors(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		to     time.Time
+		events []Event
+		want   string
+	}{
+		{name: "empty window", to: from, want: "the window must end after it starts"},
+		{name: "no schedule", to: from.AddDate(0, 0, 1), events: []Event{{Name: "job"}}, want: "every event needs a name and a schedule"},
+		{
+			name:   "too many activations",
+			to:     from.AddDate(0, 0, 1),
+			events: []Event{{Name: "job", Schedule: cron.MustParse("* * * 1W * ?", cron.WithSeconds())}},
+			want:   "job activates more than 10000 times in the window",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Write(&bytes.Buffer{}, from, tt.to, tt.events)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+
+	failed := errors.New("disk full")
+	err := Write(failingWriter{failed}, from, from.AddDate(0, 0, 1), nil)
+	assert.ErrorIs(t, err, failed)
+}
+
+func uid(name, key string) string {
+	b := builder{}
+	b.add(Event{Name: name}, time.Time{}.UTC(), "", key)
+	return strings.TrimPrefix(b.events[0][1], "UID:")
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,150 @@
+package cron
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"time"
+)
+
+/*
+Activation is an activation of a Jitter, Nominal is the time the schedule
+activates and Time the time it is delayed to
+*/
+type Activation struct {
+	Nominal time.Time
+	Time    time.Time
+}
+
+/*
+Jitter delays each activation of a schedule by an offset from zero up to its
+maximum, to spread the load of jobs that share a schedule. The offset is derived
+from a key, i.e. the name of a job, and the nominal activation time, so a job
+is always delayed the same for the same slot. A Jitter is not modified once it
+has been created, so it is safe for concurrent use
+*/
+type Jitter struct {
+	schedule Schedule
+	max      time.Duration
+	key      string
+	clock    Clock
+}
+
+/*
+NewJitter returns a Jitter that delays the activations of the schedule by less
+than max. Next, Prev and Now read the time from the clock, or from SystemClock
+when it is nil
+*/
+func NewJitter(schedule Schedule, max time.Duration, key string, clock Clock) (*Jitter, error) {
+	if schedule == nil {
+		return nil, errors.New("jitter requires a schedule")
+	}
+	if max < 0 {
+		return nil, errors.New("jitter must not be negative")
+	}
+	return &Jitter{
+		schedule: schedule,
+		max:      max,
+		key:      key,
+		clock:    clock,
+	}, nil
+}
+
+/*
+Offset returns the delay of the activation at the nominal time
+*/
+func (j *Jitter) Offset(nominal time.Time) time.Duration {
+	if j.max == 0 {
+		return 0
+	}
+	var instant [8]byte
+	binary.BigEndian.PutUint64(instant[:], uint64(nominal.UnixNano()))
+	h := fnv.New64a()
+	h.Write([]byte(j.key))
+	h.Write([]byte{0})
+	h.Write(instant[:])
+	return time.Duration(h.Sum64() % uint64(j.max))
+}
+
+/*
+NextActivationFrom returns the first activation delayed to after from, which
+may be nominally before it. If there is none, the zero Activation is returned
+*/
+func (j *Jitter) NextActivationFrom(from time.Time) Activation {
+	var next Activation
+	// A later nominal time may be delayed less and come first, so look at those
+	// that could be delayed to after from until none can come before the best
+	for nominal := j.schedule.NextFrom(from.Add(-j.max)); !nominal.IsZero(); nominal = j.schedule.NextFrom(nominal) {
+		if !next.Time.IsZero() && !nominal.Before(next.Time) {
+			break
+		}
+		t := nominal.Add(j.Offset(nominal))
+		if t.After(from) && (next.Time.IsZero() || t.Before(next.Time)) {
+			next = Activation{Nominal: nominal, Time: t}
+		}
+	}
+	return next
+}
+
+/*
+NextActivation returns the next activation delayed to after now
+*/
+func (j *Jitter) NextActivation() Activation {
+	return j.NextActivationFrom(j.now())
+}
+
+/*
+NextFrom accepts a time in which it will calculate the next delayed activation time after.
+If there is none, the zero time is returned
+*/
+func (j *Jitter) NextFrom(from time.Time) time.Time {
+	return j.NextActivationFrom(from).Time
+}
+
+/*
+PrevBefore accepts a time in which it will calculate the previous delayed activation time before.
+If there is none, the zero time is returned
+*/
+func (j *Jitter) PrevBefore(before time.Time) time.Time {
+	var prev time.Time
+	for nominal := j.schedule.PrevBefore(before); !nominal.IsZero(); nominal = j.schedule.PrevBefore(nominal) {
+		if !prev.IsZero() && !nominal.Add(j.max).After(prev) {
+			break
+		}
+		if t := nominal.Add(j.Offset(nominal)); t.Before(before) && t.After(prev) {
+			prev = t
+		}
+	}
+	return prev
+}
+
+/*
+Next will return the next delayed activation after now
+*/
+func (j *Jitter) Next() time.Time {
+	return j.NextFrom(j.now())
+}
+
+/*
+Prev will return the previous delayed activation before now
+*/
+func (j *Jitter) Prev() time.Time {
+	return j.PrevBefore(j.now())
+}
+
+/*
+Now will tell you a delayed activation falls within the current minute
+*/
+func (j *Jitter) Now() bool {
+	minute := j.now().Truncate(time.Minute)
+	next := j.NextFrom(minute.Add(-1))
+	return !next.IsZero() && next.Before(minute.Add(time.Minute))
+}
+
+func (j *Jitter) now() time.Time {
+	clock := j.clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	return clock.Now()
+}
@@ -0,0 +1,112 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestJitter_Offset(t *testing.T) {
+	schedule := MustParse("*/5 * * * *")
+	backup, err := NewJitter(schedule, 90*time.Second, "backup", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	report, err := NewJitter(schedule, 90*time.Second, "report", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	differ := 0
+	nominal := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		offset := backup.Offset(nominal)
+		assert.True(t, offset >= 0 && offset < 90*time.Second, "offset %v", offset)
+		// The same key and slot are always delayed the same, whatever the location
+		assert.Equal(t, offset, backup.Offset(nominal.In(time.FixedZone("", 3600))))
+		if offset != report.Offset(nominal) {
+			differ++
+		}
+		nominal = schedule.NextFrom(nominal)
+	}
+	assert.Greater(t, differ, 90)
+
+	none, err := NewJitter(schedule, 0, "backup", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, time.Duration(0), none.Offset(nominal))
+	}
+}
+
+func TestJitter_NextActivationFrom(t *testing.T) {
+	jitter, err := NewJitter(MustParse("0 * * * *"), 10*time.Minute, "backup", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	nominal := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	delayed := nominal.Add(jitter.Offset(nominal))
+
+	next := jitter.NextActivationFrom(nominal.Add(-time.Second))
+	assert.Equal(t, Activation{Nominal: nominal, Time: delayed}, next)
+	// The activation is still to come after its nominal time
+	assert.Equal(t, next, jitter.NextActivationFrom(delayed.Add(-time.Nanosecond)))
+	assert.Equal(t, nominal.Add(time.Hour), jitter.NextActivationFrom(delayed).Nominal)
+
+	assert.True(t, delayed.Equal(jitter.PrevBefore(delayed.Add(time.Nanosecond))))
+	earlier := nominal.Add(-time.Hour)
+	assert.True(t, earlier.Add(jitter.Offset(earlier)).Equal(jitter.PrevBefore(delayed)))
+}
+
+// TestJitter_Order checks that every activation is returned once and in order,
+// when the jitter is larger than the time between them
+func TestJitter_Order(t *testing.T) {
+	schedule := MustParse("* * * * * *", WithSeconds())
+	jitter, err := NewJitter(schedule, 10*time.Second, "backup", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var want []time.Time
+	for nominal := schedule.NextFrom(from.Add(-jitter.max)); nominal.Before(from.Add(time.Minute)); nominal = schedule.NextFrom(nominal) {
+		if t := nominal.Add(jitter.Offset(nominal)); t.After(from) && t.Before(from.Add(time.Minute)) {
+			want = append(want, t)
+		}
+	}
+	sort.Slice(want, func(i, j int) bool { return want[i].Before(want[j]) })
+
+	var got []time.Time
+	for next := jitter.NextFrom(from); next.Before(from.Add(time.Minute)); next = jitter.NextFrom(next) {
+		got = append(got, next)
+	}
+	assert.Equal(t, want, got)
+
+	var prev []time.Time
+	for t := jitter.PrevBefore(from.Add(time.Minute)); t.After(from); t = jitter.PrevBefore(t) {
+		prev = append([]time.Time{t}, prev...)
+	}
+	assert.Equal(t, want, prev)
+}
+
+func TestJitter_Clock(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 9, 59, 0, 0, time.UTC))
+	jitter, err := NewJitter(MustParse("0 10 * * *"), 50*time.Second, "backup", clock)
+	if !assert.NoError(t, err) {
+		return
+	}
+	nominal := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, nominal, jitter.NextActivation().Nominal)
+	assert.False(t, jitter.Now())
+
+	clock.Set(nominal.Add(jitter.Offset(nominal)).Add(time.Second))
+	assert.True(t, jitter.Now())
+	assert.True(t, nominal.Add(jitter.Offset(nominal)).Equal(jitter.Prev()))
+	assert.Equal(t, nominal.AddDate(0, 0, 1), jitter.NextActivation().Nominal)
+	assert.True(t, jitter.NextActivation().Time.Equal(jitter.Next()))
+}
+
+func TestNewJitter_Error(t *testing.T) {
+	_, err := NewJitter(MustParse("0 10 * * *"), -time.Second, "backup", nil)
+	assert.EqualError(t, err, "jitter must not be negative")
+	_, err = NewJitter(nil, time.Second, "backup", nil)
+	assert.EqualError(t, err, "jitter requires a schedule")
+}
@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// cronJSON is the JSON form of a Cron, the schedule in its canonical form
+// together with the options needed to parse it back
+type cronJSON struct {
+	Schedule  string     `json:"schedule"`
+	Seconds   bool       `json:"seconds,omitempty"`
+	Years     bool       `json:"years,omitempty"`
+	DayMatch  string     `json:"dayMatch,omitempty"`
+	Location  string     `json:"location,omitempty"`
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	NotAfter  *time.Time `json:"notAfter,omitempty"`
+}
+
+/*
+MarshalJSON writes the schedule as a JSON object of its expression, its location,
+its NotBefore and NotAfter bounds and the options the expression is parsed with.
+An error matching UnrepresentableSchedule is returned for the days excluded by a
+calendar and for business days, which are not part of it
+*/
+func (c *Cron) MarshalJSON() ([]byte, error) {
+	if len(c.calendars) > 0 {
+		return nil, fmt.Errorf("%w in JSON: excludes the days of a calendar", UnrepresentableSchedule)
+	}
+	if c.businessDays {
+		return nil, fmt.Errorf("%w in JSON: keeps to business days", UnrepresentableSchedule)
+	}
+
+	out := cronJSON{
+		Schedule: c.String(),
+		Seconds:  c.seconds,
+		Years:    c.years,
+		Location: c.loc.String(),
+	}
+	if c.dayMatch == DayMatchOr {
+		out.DayMatch = "or"
+	}
+	if !c.notBefore.IsZero() {
+		out.NotBefore = &c.notBefore
+	}
+	if !c.notAfter.IsZero() {
+		out.NotAfter = &c.notAfter
+	}
+	return json.Marshal(out)
+}
+
+/*
+UnmarshalJSON reads a schedule written by MarshalJSON
+*/
+func (c *Cron) UnmarshalJSON(data []byte) error {
+	var in cronJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	var opts []Option
+	if in.Seconds {
+		opts = append(opts, WithSeconds())
+	}
+	if in.Years {
+		opts = append(opts, WithYears())
+	}
+	switch in.DayMatch {
+	case "", "and":
+	case "or":
+		opts = append(opts, WithDayMatch(DayMatchOr))
+	default:
+		return fmt.Errorf("unknown dayMatch %q", in.DayMatch)
+	}
+	if in.Location != "" {
+		loc, err := time.LoadLocation(in.Location)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, WithLocation(loc))
+	}
+	if in.NotBefore != nil {
+		opts = append(opts, WithNotBefore(*in.NotBefore))
+	}
+	if in.NotAfter != nil {
+		opts = append(opts, WithNotAfter(*in.NotAfter))
+	}
+
+	cron, err := Parse(in.Schedule, opts...)
+	if err != nil {
+		return err
+	}
+	*c = *cron
+	return nil
+}
@@ -0,0 +1,78 @@
+package cron
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCron_MarshalJSON(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(t, err) {
+		return
+	}
+	tests := []struct {
+		name string
+		cron *Cron
+		want string
+	}{
+		{
+			name: "standard",
+			cron: MustParse("*/15 9-17 * * MON-FRI"),
+			want: `{"schedule":"*/15 9-17 * * 1-5","location":"UTC"}`,
+		},
+		{
+			name: "options",
+			cron: MustParse("30 0 0 1 * 5 2024-2026", WithSeconds(), WithYears(), WithDayMatch(DayMatchOr), WithLocation(newYork)),
+			want: `{"schedule":"30 0 0 1 * 5 2024-2026","seconds":true,"years":true,"dayMatch":"or","location":"America/New_York"}`,
+		},
+		{
+			name: "bounds",
+			cron: MustParse("0 12 * * 1", WithNotBefore(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)), WithNotAfter(time.Date(2024, 3, 31, 12, 0, 0, 0, newYork))),
+			want: `{"schedule":"0 12 * * 1","location":"UTC","notBefore":"2024-03-01T00:00:00Z","notAfter":"2024-03-31T12:00:00-04:00"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.cron)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.JSONEq(t, tt.want, string(data))
+
+			var read Cron
+			if assert.NoError(t, json.Unmarshal(data, &read)) {
+				assert.Equal(t, tt.cron.String(), read.String())
+				assert.Equal(t, tt.cron.loc.String(), read.loc.String())
+				assert.True(t, tt.cron.NotBefore().Equal(read.NotBefore()))
+				assert.True(t, tt.cron.NotAfter().Equal(read.NotAfter()))
+				from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+				assert.True(t, tt.cron.NextFrom(from).Equal(read.NextFrom(from)))
+			}
+		})
+	}
+}
+
+func TestCron_MarshalJSON_Error(t *testing.T) {
+	_, err := json.Marshal(MustParse("0 6 1 * *", WithBusinessDays(RollForward)))
+	assert.ErrorIs(t, err, UnrepresentableSchedule)
+	_, err = json.Marshal(MustParse("0 6 1 * *", WithCalendar(Dates())))
+	assert.ErrorIs(t, err, UnrepresentableSchedule)
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "schedule", data: `{"schedule":"0 25 * * *"}`},
+		{name: "day match", data: `{"schedule":"0 0 * * *","dayMatch":"xor"}`},
+		{name: "location", data: `{"schedule":"0 0 * * *","location":"Mars/Olympus_Mons"}`},
+		{name: "not an object", data: `"0 0 * * *"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cron Cron
+			assert.Error(t, json.Unmarshal([]byte(tt.data), &cron))
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// kubernetesYears is how many years past the current one robfig/cron, and so
+// the CronJob controller, looks for an activation before giving up
+const kubernetesYears = 5
+
+// kubernetesFields are the fields of a CronJob schedule, in order
+var kubernetesFields = []struct {
+	part     partType
+	min, max int
+	names    []string
+}{
+	{minute, 0, 59, nil},
+	{hour, 0, 23, nil},
+	{day, 1, 31, nil},
+	{month, 1, 12, monthNames},
+	{weekday, 0, 6, weekdayNames},
+}
+
+/*
+ParseKubernetes takes the .spec.schedule and .spec.timeZone of a Kubernetes
+CronJob and returns a Schedule if the API server would accept them; otherwise,
+it returns an error. timeZone is nil when the CronJob does not set it, in which
+case the location given with WithLocation stands in for the time zone of the
+controller, UTC by default. Options may be given to change how the schedule is
+calculated, the day fields are always combined as the controller does.
+
+Schedules are read with the rules of robfig/cron, which the controller uses:
+
+* [0-59] (* , / - ?)
+
+* [0-23] (* , / - ?)
+
+* [1-31] (* , / - ?)
+
+* [1-12] (* , / - ?)    JAN-DEC
+
+* [0-6]  (* , / - ?)    SUN-SAT
+
+A step counts from the start of its range, a step from a single value runs to
+the end of the field and a step may be larger than the field. The day fields
+are combined with DayMatchOr unless either of them starts with * or ?. None of
+the extensions of Parse are accepted, nor TZ= or CRON_TZ= in the schedule.
+
+The macros @yearly (or @annually), @monthly, @weekly, @daily (or @midnight) and
+@hourly may stand in for the whole schedule, they return a *Cron. @every 90m
+returns a *Rate of the duration, rounded down to the second and at least one
+second. The controller counts it from the creation of the CronJob, the returned
+Rate counts from the Unix epoch; use NewRate with its Interval to count from
+another time.
+
+As in the controller, activations more than five years past the current one are
+not found.
+*/
+func ParseKubernetes(schedule string, timeZone *string, opts ...Option) (Schedule, error) {
+	if schedule == "" {
+		return nil, EmptyCronSchedule
+	}
+	if strings.Contains(schedule, "TZ") {
+		return nil, &ParseError{Schedule: schedule, Err: errors.New("cannot use TZ or CRON_TZ in schedule, use timeZone field instead")}
+	}
+
+	conf := newConfig(opts)
+	cron := conf.Cron
+	if timeZone != nil {
+		loc, err := kubernetesTimeZone(*timeZone)
+		if err != nil {
+			return nil, err
+		}
+		cron.loc = loc
+	}
+
+	if every, ok := strings.CutPrefix(schedule, "@every "); ok {
+		return parseKubernetesEvery(schedule, every, cron)
+	}
+
+	cron.seconds = false
+	cron.years = false
+	cron.year = yearSet{}
+	cron.dayMatch = DayMatchOr
+	cron.searchLimit = kubernetesYears
+	if strings.HasPrefix(schedule, "@") {
+		if _, ok := macros[schedule]; !ok {
+			return nil, &ParseError{Schedule: schedule, Err: fmt.Errorf("unrecognized descriptor %q", schedule)}
+		}
+		return parseMacro(conf, schedule)
+	}
+
+	// Fields are separated by any whitespace, as with strings.Fields
+	cronParts := strings.Fields(schedule)
+	if len(cronParts) != len(kubernetesFields) {
+		return nil, &ParseError{
+			Schedule: schedule,
+			Err:      fmt.Errorf("expected exactly 5 fields, found %d", len(cronParts)),
+		}
+	}
+
+	cron.second = newSet[uint8](0)
+	sets := []*set[uint8]{&cron.minute, &cron.hour, &cron.day, &cron.month, &cron.weekday}
+	stars := []*bool{nil, nil, &cron.dayStar, nil, &cron.weekdayStar}
+	offset := 0
+	for i, cronPart := range cronParts {
+		offset += strings.Index(schedule[offset:], cronPart)
+		field := kubernetesFields[i]
+		timeSet, star, err := parseKubernetesPart(cronPart, field.min, field.max, field.names)
+		if err != nil {
+			return nil, &ParseError{Schedule: schedule, Field: string(field.part), Offset: offset, Err: err}
+		}
+		*sets[i] = timeSet
+		if stars[i] != nil {
+			*stars[i] = star
+		}
+		offset += len(cronPart)
+	}
+	return cron, nil
+}
+
+// kubernetesTimeZone validates the timeZone of a CronJob as the API server does
+func kubernetesTimeZone(timeZone string) (*time.Location, error) {
+	switch {
+	case timeZone == "":
+		return nil, errors.New("timeZone must be nil or non-empty string")
+	case strings.EqualFold(timeZone, "Local"):
+		return nil, errors.New("timeZone must be an explicit time zone as defined in https://www.iana.org/time-zones")
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeZone %q: %w", timeZone, err)
+	}
+	return loc, nil
+}
+
+// parseKubernetesEvery parses the duration of an @every schedule into a Rate
+func parseKubernetesEvery(schedule, every string, cron *Cron) (*Rate, error) {
+	interval, err := time.ParseDuration(every)
+	if err != nil {
+		return nil, &ParseError{Schedule: schedule, Err: err}
+	}
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return NewRate(interval.Truncate(time.Second), time.Unix(0, 0).In(cron.loc), cron.clock)
+}
+
+// parseKubernetesPart parses a field as robfig/cron does, and reports whether
+// it contains a * or ? without a step, which decides how the day fields combine.
+// Empty items of a list are skipped, so a field of only commas matches nothing.
+func parseKubernetesPart(cronPart string, min, max int, names []string) (set[uint8], bool, error) {
+	var timeSet set[uint8]
+	star := false
+	for _, item := range strings.FieldsFunc(cronPart, func(r rune) bool { return r == ',' }) {
+		rangeAndStep := strings.Split(item, "/")
+		lowAndHigh := strings.Split(rangeAndStep[0], "-")
+		itemStar := false
+
+		var start, end int
+		var err error
+		if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
+			// robfig/cron ignores anything after the - of a * range
+			start, end, itemStar = min, max, true
+		} else {
+			if start, err = kubernetesValue(lowAndHigh[0], min, names); err != nil {
+				return timeSet, false, err
+			}
+			switch len(lowAndHigh) {
+			case 1:
+				end = start
+			case 2:
+				if end, err = kubernetesValue(lowAndHigh[1], min, names); err != nil {
+					return timeSet, false, err
+				}
+			default:
+				return timeSet, false, fmt.Errorf("too many hyphens: %s", item)
+			}
+		}
+
+		step := 1
+		switch len(rangeAndStep) {
+		case 1:
+		case 2:
+			if step, err = kubernetesValue(rangeAndStep[1], min, nil); err != nil {
+				return timeSet, false, err
+			}
+			// A step from a single value runs to the end of the field
+			if len(lowAndHigh) == 1 {
+				end = max
+			}
+			if step > 1 {
+				itemStar = false
+			}
+		default:
+			return timeSet, false, fmt.Errorf("too many slashes: %s", item)
+		}
+
+		switch {
+		case start < min:
+			return timeSet, false, fmt.Errorf("beginning of range (%d) below minimum (%d): %s", start, min, item)
+		case end > max:
+			return timeSet, false, fmt.Errorf("end of range (%d) above maximum (%d): %s", end, max, item)
+		case start > end:
+			return timeSet, false, fmt.Errorf("beginning of range (%d) beyond end of range (%d): %s", start, end, item)
+		case step == 0:
+			return timeSet, false, fmt.Errorf("step of range should be a positive number: %s", item)
+		}
+
+		// A step past the end of the field selects the start alone
+		if step > max {
+			step = max + 1
+		}
+		for value := start; value <= end; value += step {
+			timeSet.add(uint8(value))
+		}
+		star = star || itemStar
+	}
+	return timeSet, star, nil
+}
+
+// kubernetesValue parses a number, or a name of the field in any case, as robfig/cron
+// does, the names are numbered from min
+func kubernetesValue(a string, min int, names []string) (int, error) {
+	for i, name := range names {
+		if strings.EqualFold(a, name) {
+			return min + i, nil
+		}
+	}
+	value, err := strconv.Atoi(a)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse int from %s: %w", a, err)
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("negative number (%d) not allowed: %s", value, a)
+	}
+	return value, nil
+}
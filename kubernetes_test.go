@@ -0,0 +1,169 @@
+package cron
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestParseKubernetes_Conformance checks the next activation against the one
+// robfig/cron v3.0.1 gives with ParseStandard, which the CronJob controller uses
+func TestParseKubernetes_Conformance(t *testing.T) {
+	from := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+	tests := []struct {
+		schedule string
+		timeZone string
+		from     time.Time
+		want     time.Time
+	}{
+		{schedule: "*/15 * * * *", want: time.Date(2023, 6, 17, 18, 30, 0, 0, time.UTC)},
+		{schedule: "0 9 * * 1-5", want: time.Date(2023, 6, 19, 9, 0, 0, 0, time.UTC)},
+		{schedule: "0 9 * * MON-FRI", timeZone: "America/New_York", want: time.Date(2023, 6, 19, 13, 0, 0, 0, time.UTC)},
+		{schedule: "0 0 1 jan,jul *", want: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{schedule: "5/15 * * * *", want: time.Date(2023, 6, 17, 18, 35, 0, 0, time.UTC)},
+		// A step counts from the start of its range
+		{schedule: "1-10/4 * * * *", want: time.Date(2023, 6, 17, 19, 1, 0, 0, time.UTC)},
+		{schedule: "*/100 * * * *", want: time.Date(2023, 6, 17, 19, 0, 0, 0, time.UTC)},
+		{schedule: "? ? ? ? ?", want: time.Date(2023, 6, 17, 18, 24, 0, 0, time.UTC)},
+		{schedule: "1,,2 * * * *", want: time.Date(2023, 6, 17, 19, 1, 0, 0, time.UTC)},
+		{schedule: "+5 * * * *", want: time.Date(2023, 6, 17, 19, 5, 0, 0, time.UTC)},
+		{schedule: "*-5 * * * *", want: time.Date(2023, 6, 17, 18, 24, 0, 0, time.UTC)},
+		{schedule: "0\t9  * * 1-5", want: time.Date(2023, 6, 19, 9, 0, 0, 0, time.UTC)},
+		// The day fields match either unless one of them starts with * or ?
+		{schedule: "0 0 1,15 * MON", want: time.Date(2023, 6, 19, 0, 0, 0, 0, time.UTC)},
+		{schedule: "0 0 */2 * MON", want: time.Date(2023, 6, 19, 0, 0, 0, 0, time.UTC)},
+		{schedule: "0 0 *,1 * MON", want: time.Date(2023, 6, 19, 0, 0, 0, 0, time.UTC)},
+		{schedule: "0 0 29 2 1", want: time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)},
+		{schedule: "0 0 30 2 *", want: time.Time{}},
+		{schedule: "@weekly", want: time.Date(2023, 6, 18, 0, 0, 0, 0, time.UTC)},
+		{schedule: "@monthly", timeZone: "Europe/Berlin", want: time.Date(2023, 6, 30, 22, 0, 0, 0, time.UTC)},
+		{schedule: "@hourly", want: time.Date(2023, 6, 17, 19, 0, 0, 0, time.UTC)},
+		// 2:30am does not happen on the day daylight saving starts
+		{
+			schedule: "30 2 * * *",
+			timeZone: "America/New_York",
+			from:     time.Date(2023, 3, 11, 12, 0, 0, 0, time.UTC),
+			want:     time.Date(2023, 3, 13, 6, 30, 0, 0, time.UTC),
+		},
+		// The next 29th of February is 2104, past the five years searched
+		{
+			schedule: "0 0 29 2 *",
+			from:     time.Date(2096, 3, 1, 0, 0, 0, 0, time.UTC),
+			want:     time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schedule, func(t *testing.T) {
+			var timeZone *string
+			if tt.timeZone != "" {
+				timeZone = &tt.timeZone
+			}
+			schedule, err := ParseKubernetes(tt.schedule, timeZone)
+			if !assert.NoError(t, err) {
+				return
+			}
+			start := tt.from
+			if start.IsZero() {
+				start = from
+			}
+			assert.True(t, tt.want.Equal(schedule.NextFrom(start)), "got %v, want %v", schedule.NextFrom(start), tt.want)
+		})
+	}
+}
+
+func TestParseKubernetes_PrevBefore(t *testing.T) {
+	tests := []struct {
+		schedule string
+		before   time.Time
+		want     time.Time
+	}{
+		{schedule: "0 9 * * 1-5", before: time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC), want: time.Date(2023, 6, 16, 9, 0, 0, 0, time.UTC)},
+		{schedule: "0 0 29 2 *", before: time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC), want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// The previous 29th of February is in 2096, further back than the five years searched
+		{schedule: "0 0 29 2 *", before: time.Date(2104, 2, 1, 0, 0, 0, 0, time.UTC), want: time.Time{}},
+		{schedule: ", * * * *", before: time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC), want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schedule, func(t *testing.T) {
+			schedule, err := ParseKubernetes(tt.schedule, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.True(t, tt.want.Equal(schedule.PrevBefore(tt.before)), "got %v, want %v", schedule.PrevBefore(tt.before), tt.want)
+		})
+	}
+}
+
+func TestParseKubernetes_Rejected(t *testing.T) {
+	tests := []struct {
+		schedule string
+		timeZone *string
+		want     string
+	}{
+		{schedule: "0 0 * * 7", want: "end of range (7) above maximum (6): 7"},
+		{schedule: "L * * * *", want: "failed to parse int from L"},
+		{schedule: "0 0 ? * 5#2", want: "failed to parse int from 5#2"},
+		{schedule: "H * * * *", want: "failed to parse int from H"},
+		{schedule: "0~5 * * * *", want: "failed to parse int from 0~5"},
+		{schedule: "22-2 * * * *", want: "beginning of range (22) beyond end of range (2): 22-2"},
+		{schedule: "60 * * * *", want: "end of range (60) above maximum (59): 60"},
+		{schedule: "* * 0 * *", want: "beginning of range (0) below minimum (1): 0"},
+		{schedule: "1-2-3 * * * *", want: "too many hyphens: 1-2-3"},
+		{schedule: "1/2/3 * * * *", want: "too many slashes: 1/2/3"},
+		{schedule: "*/0 * * * *", want: "step of range should be a positive number: */0"},
+		{schedule: "mon * * * *", want: "failed to parse int from mon"},
+		{schedule: "* * * * * *", want: "expected exactly 5 fields, found 6"},
+		{schedule: "@reboot", want: "unrecognized descriptor"},
+		{schedule: "@Daily", want: "unrecognized descriptor"},
+		{schedule: "@every 1 hour", want: `time: unknown unit " hour"`},
+		{schedule: "CRON_TZ=UTC 0 0 * * *", want: "cannot use TZ or CRON_TZ in schedule"},
+		{schedule: "0 0 * * *", timeZone: ptrTo(""), want: "timeZone must be nil or non-empty string"},
+		{schedule: "0 0 * * *", timeZone: ptrTo("local"), want: "timeZone must be an explicit time zone"},
+		{schedule: "0 0 * * *", timeZone: ptrTo("Mars/Olympus_Mons"), want: `invalid timeZone "Mars/Olympus_Mons"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schedule, func(t *testing.T) {
+			_, err := ParseKubernetes(tt.schedule, tt.timeZone)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseKubernetes_Field(t *testing.T) {
+	_, err := ParseKubernetes("0  25 * * *", nil)
+	var parseErr *ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, "hour", parseErr.Field)
+		assert.Equal(t, 3, parseErr.Offset)
+	}
+	assert.ErrorIs(t, err, InvalidCronSchedule)
+
+	_, err = ParseKubernetes("", nil)
+	assert.ErrorIs(t, err, EmptyCronSchedule)
+}
+
+func TestParseKubernetes_Every(t *testing.T) {
+	tests := []struct {
+		schedule string
+		want     time.Duration
+	}{
+		{schedule: "@every 90m", want: 90 * time.Minute},
+		{schedule: "@every 1.5s", want: time.Second},
+		{schedule: "@every 0", want: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schedule, func(t *testing.T) {
+			schedule, err := ParseKubernetes(tt.schedule, nil)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, schedule.(*Rate).Interval())
+			}
+		})
+	}
+}
+
+func ptrTo(s string) *string {
+	return &s
+}
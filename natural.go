@@ -0,0 +1,972 @@
+package cron
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// naturalUnit is the unit of time an "every" phrase repeats by
+type naturalUnit int
+
+const (
+	noUnit naturalUnit = iota
+	secondUnit
+	minuteUnit
+	hourUnit
+	dayUnit
+	weekUnit
+	monthUnit
+	yearUnit
+)
+
+var naturalUnits = map[string]naturalUnit{
+	"sec": secondUnit, "secs": secondUnit, "second": secondUnit, "seconds": secondUnit,
+	"min": minuteUnit, "mins": minuteUnit, "minute": minuteUnit, "minutes": minuteUnit,
+	"hr": hourUnit, "hrs": hourUnit, "hour": hourUnit, "hours": hourUnit,
+	"day": dayUnit, "days": dayUnit,
+	"week": weekUnit, "weeks": weekUnit,
+	"month": monthUnit, "months": monthUnit,
+	"year": yearUnit, "years": yearUnit,
+}
+
+// naturalAdverbs are the single words that give the unit, i.e. daily
+var naturalAdverbs = map[string]naturalUnit{
+	"hourly": hourUnit, "daily": dayUnit, "nightly": dayUnit, "weekly": weekUnit,
+	"monthly": monthUnit, "yearly": yearUnit, "annually": yearUnit,
+}
+
+var naturalNumbers = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8,
+	"nine": 9, "ten": 10, "eleven": 11, "twelve": 12, "fifteen": 15, "twenty": 20, "thirty": 30,
+	"other": 2,
+}
+
+var naturalOrdinals = map[string]int{"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5}
+
+// naturalUnsupported are words for schedules that cron cannot express, and why
+var naturalUnsupported = map[string]string{
+	"biweekly":    "biweekly is ambiguous, it may mean twice a week or every other week",
+	"fortnightly": "every other week cannot be written as a schedule, the weeks of a month restart each month",
+	"twice":       "give the times instead, i.e. at 9am and 5pm",
+	"once":        "give the time instead, i.e. daily at 9am",
+	"except":      "exceptions are not supported, see WithCalendar",
+}
+
+/*
+ParseNatural reads a schedule written in English, such as "every 15 minutes",
+"weekdays at 9:30am" or "first Monday of every month at noon". A phrase that a
+schedule cannot express, that may be read more than one way or that never happens,
+such as the 30th of February, is reported with a ParseError at the word it failed
+on. The options apply as for Parse, except for WithSeconds and WithYears, which are
+decided by the phrase.
+
+A time is written 9am, 9:30pm, 17:45, noon or midnight, a bare hour from 1 to 12 is
+ambiguous. A schedule given days but no time activates at midnight. A range of
+hours (between 9am and 5pm) runs until the end, not including it.
+*/
+func ParseNatural(text string, opts ...Option) (*Cron, error) {
+	p, err := parseNaturalSchedule(text)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := p.schedule.fields(p)
+	if err != nil {
+		return nil, err
+	}
+	return p.cron(parts, opts)
+}
+
+// parseNaturalSchedule reads what a phrase says, before it is turned into fields
+func parseNaturalSchedule(text string) (*naturalParser, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, EmptyCronSchedule
+	}
+	p := &naturalParser{text: text, tokens: tokenizeNatural(text), schedule: naturalSchedule{pastMinute: -1}}
+	for p.pos < len(p.tokens) {
+		if err := p.parseClause(); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// cron parses the fields of the phrase, with the seconds field first when there are six
+func (p *naturalParser) cron(parts []string, opts []Option) (*Cron, error) {
+	conf := newConfig(opts)
+	cron := conf.Cron
+	cron.seconds = len(parts) == 6
+	cron.years = false
+	cron.dayMatch = DayMatchAnd
+	// A field that fails is reported at the word of the phrase it comes from
+	fields := []partType{minute, hour, day, month, weekday}
+	if cron.seconds {
+		fields = append([]partType{second}, fields...)
+	}
+	offsets := make([]int, len(parts))
+	for i, field := range fields {
+		offsets[i] = p.schedule.fieldAt(field).offset
+	}
+	if err := standard.parseFields(conf, p.text, parts, offsets); err != nil {
+		return nil, err
+	}
+	if !daysInMonths(cron) {
+		return nil, p.errorf(p.schedule.daysAt, "the days are in none of the months, the schedule never activates")
+	}
+	return cron, nil
+}
+
+// daysInMonths reports whether the days of the month of the Cron fall in any of its
+// months, which they do not for the 30th of February. Days counted from the end of
+// the month are in every month
+func daysInMonths(c *Cron) bool {
+	if c.day.bits == 0 || c.lastDays.bits != 0 || c.nearestWeekdays.bits != 0 || c.lastWeekday {
+		return true
+	}
+	first := bits.TrailingZeros64(c.day.bits)
+	for m := time.January; m <= time.December; m++ {
+		// A leap year, for the 29th of February
+		if c.month.contains(uint8(m)) && first <= daysIn(2000, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// naturalToken is a word of the phrase and where it starts
+type naturalToken struct {
+	word   string
+	offset int
+}
+
+// tokenizeNatural splits a phrase into lower case words, a comma is a word of its own
+func tokenizeNatural(text string) []naturalToken {
+	var tokens []naturalToken
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			word := strings.TrimRight(strings.ToLower(text[start:end]), ".")
+			if word != "" {
+				tokens = append(tokens, naturalToken{word: word, offset: start})
+			}
+			start = -1
+		}
+	}
+	for i, r := range text {
+		switch {
+		case r == ',':
+			flush(i)
+			tokens = append(tokens, naturalToken{word: ",", offset: i})
+		case r == ' ' || r == '\t' || r == '\n':
+			flush(i)
+		case start < 0:
+			start = i
+		}
+	}
+	flush(len(text))
+	return tokens
+}
+
+// naturalTime is a time of day, second is -1 when it is not given
+type naturalTime struct {
+	hour, minute, second int
+}
+
+// naturalSchedule is what the phrase says, before it is turned into fields
+type naturalSchedule struct {
+	unit     naturalUnit
+	interval int
+	unitAt   naturalToken
+	// intervalAt is the number of units, unitAt when there is none
+	intervalAt naturalToken
+
+	times []naturalTime
+	// pastMinute is the minute of an hourly schedule (at :30), -1 when not given
+	pastMinute int
+	hourRange  []int
+	timesAt    naturalToken
+	hoursAt    naturalToken
+
+	days     []string
+	weekdays []string
+	months   []string
+	daysAt   naturalToken
+	monthsAt naturalToken
+}
+
+type naturalParser struct {
+	text     string
+	tokens   []naturalToken
+	pos      int
+	schedule naturalSchedule
+}
+
+func (p *naturalParser) errorf(at naturalToken, format string, args ...any) error {
+	return &ParseError{Schedule: p.text, Offset: at.offset, Err: fmt.Errorf(format, args...)}
+}
+
+// peek returns the word at the position, or "" at the end of the phrase
+func (p *naturalParser) peek() string {
+	return p.peekAt(0)
+}
+
+func (p *naturalParser) peekAt(n int) string {
+	if p.pos+n >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos+n].word
+}
+
+// token returns the token at the position, or one at the end of the phrase
+func (p *naturalParser) token() naturalToken {
+	if p.pos >= len(p.tokens) {
+		return naturalToken{offset: len(p.text)}
+	}
+	return p.tokens[p.pos]
+}
+
+// accept moves past the word at the position if it is one of words
+func (p *naturalParser) accept(words ...string) bool {
+	for _, word := range words {
+		if p.peek() == word {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+// acceptSeparator moves past "and" or a comma when another item of a list follows
+func (p *naturalParser) acceptSeparator(item func(word string) bool) bool {
+	for n := 0; p.peekAt(n) == "and" || p.peekAt(n) == ","; n++ {
+		if next := p.peekAt(n + 1); next != "and" && next != "," && item(next) {
+			p.pos += n + 1
+			return true
+		}
+	}
+	return false
+}
+
+// parseClause reads one part of the phrase, i.e. "every 15 minutes" or "at 9am"
+func (p *naturalParser) parseClause() error {
+	tok := p.token()
+	word := tok.word
+	switch {
+	case word == "and" || word == ",":
+		p.pos++
+		return nil
+	case word == "every" || word == "each":
+		p.pos++
+		return p.parseEvery(tok)
+	case naturalAdverbs[word] != noUnit:
+		p.pos++
+		return p.setUnit(tok, naturalAdverbs[word], 1)
+	case word == "at":
+		p.pos++
+		return p.parseTimes(tok)
+	case word == "on":
+		p.pos++
+		return p.parseDays()
+	case word == "in" || word == "during":
+		p.pos++
+		return p.parseMonths()
+	case word == "between" || word == "from":
+		p.pos++
+		return p.parseHourRange(tok)
+	case word == "the":
+		p.pos++
+		return p.parseDays()
+	case word == "noon" || word == "midnight" || isNaturalTime(word):
+		return p.parseTimes(tok)
+	case naturalUnsupported[word] != "":
+		return p.errorf(tok, "%s", naturalUnsupported[word])
+	}
+	return p.parseDays()
+}
+
+// parseEvery reads what follows every: a unit, a number of units, or days
+func (p *naturalParser) parseEvery(every naturalToken) error {
+	tok := p.token()
+	switch {
+	case tok.word == "half" || tok.word == "quarter":
+		p.pos++
+		if !p.accept("hour") {
+			return p.errorf(p.token(), "expected hour after %s", tok.word)
+		}
+		if tok.word == "half" {
+			return p.setUnit(every, minuteUnit, 30)
+		}
+		return p.setUnit(every, minuteUnit, 15)
+	case tok.word == "second" && isNaturalWeekday(p.peekAt(1)):
+		return p.errorf(tok, "every second %s is ambiguous, it may mean every other %[1]s or the second of the month", p.peekAt(1))
+	case naturalUnits[tok.word] != noUnit:
+		p.pos++
+		return p.setUnit(every, naturalUnits[tok.word], 1)
+	}
+	if n, ok := parseNaturalNumber(tok.word); ok {
+		p.pos++
+		unit := naturalUnits[p.peek()]
+		if unit == noUnit {
+			return p.errorf(p.token(), "expected a unit of time after %s", tok.word)
+		}
+		p.pos++
+		if err := p.setUnit(every, unit, n); err != nil {
+			return err
+		}
+		p.schedule.intervalAt = tok
+		return nil
+	}
+	return p.parseDays()
+}
+
+// setUnit records the unit and interval the schedule repeats by
+func (p *naturalParser) setUnit(at naturalToken, unit naturalUnit, interval int) error {
+	if p.schedule.unit != noUnit {
+		return p.errorf(at, "how often is given twice")
+	}
+	if interval < 1 {
+		return p.errorf(at, "the interval must be at least 1")
+	}
+	p.schedule.unit, p.schedule.interval, p.schedule.unitAt, p.schedule.intervalAt = unit, interval, at, at
+	return nil
+}
+
+// parseDays reads days of the week, days of the month and months, in any of the
+// forms "mondays", "weekdays", "monday through friday", "the 1st and 15th",
+// "the last day of the month", "first monday of every month" and "january 1st"
+func (p *naturalParser) parseDays() error {
+	p.accept("the")
+	tok := p.token()
+	switch {
+	case tok.word == "":
+		return p.errorf(tok, "the phrase ends too soon")
+	case isNaturalWeekday(tok.word):
+		weekdays, err := p.parseWeekdays()
+		if err != nil {
+			return err
+		}
+		return p.setDays(tok, nil, weekdays)
+	case isNaturalMonth(tok.word):
+		return p.parseMonthDays()
+	case isNaturalOrdinal(tok.word) || tok.word == "last":
+		return p.parseOrdinals()
+	case tok.word == "day" || tok.word == "days":
+		// i.e. "every day", as a unit of its own
+		p.pos++
+		return p.setUnit(tok, dayUnit, 1)
+	}
+	return p.errorf(tok, "unexpected %q", tok.word)
+}
+
+// parseWeekdays reads a list of days of the week and ranges of them
+func (p *naturalParser) parseWeekdays() ([]string, error) {
+	var weekdays []string
+	for {
+		tok := p.token()
+		p.pos++
+		switch tok.word {
+		case "weekday", "weekdays":
+			weekdays = append(weekdays, "1-5")
+		case "weekend", "weekends":
+			weekdays = append(weekdays, "6", "0")
+		default:
+			first, last, isRange := strings.Cut(tok.word, "-")
+			start, ok := naturalWeekday(first)
+			if !ok {
+				return nil, p.errorf(tok, "expected a day of the week, got %q", tok.word)
+			}
+			if !isRange && p.accept("through", "thru", "to", "until", "till") {
+				last, isRange = p.peek(), true
+				p.pos++
+			}
+			if !isRange {
+				weekdays = append(weekdays, strconv.Itoa(start))
+				break
+			}
+			end, ok := naturalWeekday(last)
+			if !ok {
+				return nil, p.errorf(tok, "expected a day of the week to end the range, got %q", last)
+			}
+			weekdays = append(weekdays, strconv.Itoa(start)+"-"+strconv.Itoa(end))
+		}
+		if !p.acceptSeparator(isNaturalWeekday) {
+			return weekdays, nil
+		}
+	}
+}
+
+// parseMonths reads a list of months and ranges of them
+func (p *naturalParser) parseMonths() error {
+	tok := p.token()
+	var months []string
+	for {
+		item := p.token()
+		p.pos++
+		first, last, isRange := strings.Cut(item.word, "-")
+		start, ok := naturalMonth(first)
+		if !ok {
+			return p.errorf(item, "expected a month, got %q", item.word)
+		}
+		if !isRange && p.accept("through", "thru", "to", "until", "till") {
+			last, isRange = p.peek(), true
+			p.pos++
+		}
+		if isRange {
+			end, ok := naturalMonth(last)
+			if !ok {
+				return p.errorf(item, "expected a month to end the range, got %q", last)
+			}
+			months = append(months, strconv.Itoa(start)+"-"+strconv.Itoa(end))
+		} else {
+			months = append(months, strconv.Itoa(start))
+		}
+		if !p.acceptSeparator(isNaturalMonth) {
+			return p.setMonths(tok, months)
+		}
+	}
+}
+
+// parseMonthDays reads months each followed by a day, i.e. "january 1st and july 4th",
+// or months alone, i.e. "january and july"
+func (p *naturalParser) parseMonthDays() error {
+	tok := p.token()
+	var months []string
+	day := ""
+	for {
+		item := p.token()
+		month, _ := naturalMonth(item.word)
+		p.pos++
+		months = append(months, strconv.Itoa(month))
+
+		d, ok := parseNaturalDay(p.peek())
+		if !ok {
+			d, ok = parseNaturalNumber(p.peek())
+			ok = ok && p.peek() != "other" && d <= 31
+		}
+		switch {
+		case ok && day != "" && day != strconv.Itoa(d):
+			return p.errorf(p.token(), "different days in different months cannot be one schedule")
+		case ok:
+			day = strconv.Itoa(d)
+			p.pos++
+		case day != "":
+			return p.errorf(p.token(), "expected a day of %s", item.word)
+		}
+		if !p.acceptSeparator(isNaturalMonth) {
+			break
+		}
+	}
+	if day != "" {
+		if err := p.setDays(tok, []string{day}, nil); err != nil {
+			return err
+		}
+	}
+	return p.setMonths(tok, months)
+}
+
+// parseOrdinals reads days of the month (the 1st and 15th, the last day), or days
+// of the week counted within the month (first monday, last friday), optionally
+// followed by the months they are of
+func (p *naturalParser) parseOrdinals() error {
+	tok := p.token()
+	var counts, days []string
+	for {
+		item := p.token()
+		p.pos++
+		switch {
+		case item.word == "last":
+			counts = append(counts, "L")
+			days = append(days, "L")
+		case naturalOrdinals[item.word] > 0:
+			counts = append(counts, "#"+strconv.Itoa(naturalOrdinals[item.word]))
+			days = append(days, strconv.Itoa(naturalOrdinals[item.word]))
+		default:
+			day, ok := parseNaturalDay(item.word)
+			if !ok {
+				return p.errorf(item, "expected a day such as 1st or last, got %q", item.word)
+			}
+			counts = nil
+			days = append(days, strconv.Itoa(day))
+		}
+		if !p.acceptSeparator(func(word string) bool { return isNaturalOrdinal(word) || word == "last" }) {
+			break
+		}
+	}
+
+	var weekdays []string
+	next := p.token()
+	switch {
+	case isNaturalWeekday(next.word) && next.word != "weekday" && next.word != "weekdays":
+		if counts == nil {
+			return p.errorf(next, "a day of the week is counted with first to fifth or last")
+		}
+		p.pos++
+		weekday, _ := naturalWeekday(next.word)
+		for _, count := range counts {
+			if count == "L" {
+				weekdays = append(weekdays, strconv.Itoa(weekday)+"L")
+			} else {
+				weekdays = append(weekdays, strconv.Itoa(weekday)+count)
+			}
+		}
+		days = nil
+	case next.word == "weekday":
+		// The first or last weekday of the month
+		p.pos++
+		for i, day := range days {
+			switch day {
+			case "1":
+				days[i] = "1W"
+			case "L":
+				days[i] = "LW"
+			default:
+				return p.errorf(next, "only the first and last weekday of the month are supported")
+			}
+		}
+	case next.word == "day":
+		p.pos++
+	}
+
+	if p.accept("of") {
+		p.accept("the", "every", "each")
+		switch word := p.peek(); {
+		case word == "month":
+			p.pos++
+		case isNaturalMonth(word):
+			if err := p.parseMonths(); err != nil {
+				return err
+			}
+		default:
+			return p.errorf(p.token(), "expected the month or a month, got %q", word)
+		}
+	}
+	return p.setDays(tok, days, weekdays)
+}
+
+// setDays records days of the month or days of the week, which cannot both be given
+func (p *naturalParser) setDays(at naturalToken, days, weekdays []string) error {
+	s := &p.schedule
+	switch {
+	case len(days) > 0 && len(s.days) > 0, len(weekdays) > 0 && len(s.weekdays) > 0:
+		return p.errorf(at, "the days are given twice")
+	case len(days) > 0 && len(s.weekdays) > 0, len(weekdays) > 0 && len(s.days) > 0:
+		return p.errorf(at, "days of the month and days of the week together are ambiguous, they may mean either or both")
+	}
+	s.days = append(s.days, days...)
+	s.weekdays = append(s.weekdays, weekdays...)
+	s.daysAt = at
+	return nil
+}
+
+// setMonths records the months
+func (p *naturalParser) setMonths(at naturalToken, months []string) error {
+	if len(p.schedule.months) > 0 {
+		return p.errorf(at, "the months are given twice")
+	}
+	p.schedule.months = months
+	p.schedule.monthsAt = at
+	return nil
+}
+
+// parseTimes reads a list of times of day, or the minute past the hour (at :30,
+// at 15 minutes past the hour, at half past)
+func (p *naturalParser) parseTimes(at naturalToken) error {
+	s := &p.schedule
+	if len(s.times) > 0 || s.pastMinute >= 0 {
+		return p.errorf(at, "the time is given twice")
+	}
+	s.timesAt = at
+
+	if minute, ok := p.parsePastMinute(); ok {
+		s.pastMinute = minute
+		return nil
+	}
+	for {
+		t, err := p.parseTime()
+		if err != nil {
+			return err
+		}
+		s.times = append(s.times, t)
+		if !p.acceptSeparator(func(word string) bool { return word == "noon" || word == "midnight" || isNaturalTime(word) }) {
+			return nil
+		}
+	}
+}
+
+// parsePastMinute reads the minute past the hour, i.e. ":30" or "15 minutes past the hour"
+func (p *naturalParser) parsePastMinute() (int, bool) {
+	word := p.peek()
+	if minute, ok := strings.CutPrefix(word, ":"); ok {
+		if n, err := strconv.Atoi(minute); err == nil && n >= 0 && n <= 59 && len(minute) == 2 {
+			p.pos++
+			return n, true
+		}
+		return 0, false
+	}
+	minute, ok := map[string]int{"half": 30, "quarter": 15}[word]
+	if !ok {
+		n, err := strconv.Atoi(word)
+		if err != nil || n < 0 || n > 59 {
+			return 0, false
+		}
+		minute = n
+	}
+	n := 1
+	if p.peekAt(n) == "minute" || p.peekAt(n) == "minutes" || p.peekAt(n) == "min" || p.peekAt(n) == "mins" {
+		n++
+	}
+	if p.peekAt(n) != "past" {
+		return 0, false
+	}
+	p.pos += n + 1
+	if p.accept("the") {
+		p.accept("hour")
+	}
+	return minute, true
+}
+
+// parseTime reads a time of day, i.e. 9am, 9:30 pm, 17:45, noon or midnight
+func (p *naturalParser) parseTime() (naturalTime, error) {
+	tok := p.token()
+	p.pos++
+	switch tok.word {
+	case "noon", "midday":
+		return naturalTime{hour: 12, second: -1}, nil
+	case "midnight":
+		return naturalTime{hour: 0, second: -1}, nil
+	case "":
+		return naturalTime{}, p.errorf(tok, "expected a time")
+	}
+
+	clock, meridiem := tok.word, ""
+	for _, suffix := range []string{"am", "pm", "a.m", "p.m"} {
+		if before, ok := strings.CutSuffix(clock, suffix); ok {
+			clock, meridiem = before, suffix[:1]
+		}
+	}
+	if meridiem == "" {
+		switch p.peek() {
+		case "am", "a.m":
+			meridiem = "a"
+			p.pos++
+		case "pm", "p.m":
+			meridiem = "p"
+			p.pos++
+		}
+	}
+
+	parts := strings.Split(clock, ":")
+	values := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (i > 0 && (len(part) != 2 || n > 59)) || len(parts) > 3 {
+			return naturalTime{}, p.errorf(tok, "expected a time such as 9am or 17:30, got %q", tok.word)
+		}
+		values[i] = n
+	}
+	t := naturalTime{hour: values[0], second: -1}
+	if len(values) > 1 {
+		t.minute = values[1]
+	}
+	if len(values) > 2 {
+		t.second = values[2]
+	}
+
+	switch {
+	case meridiem != "" && (t.hour < 1 || t.hour > 12):
+		return naturalTime{}, p.errorf(tok, "%d is not an hour of a 12-hour clock", t.hour)
+	case meridiem == "a" && t.hour == 12:
+		t.hour = 0
+	case meridiem == "p" && t.hour != 12:
+		t.hour += 12
+	case meridiem == "" && len(values) == 1 && t.hour >= 1 && t.hour <= 12:
+		return naturalTime{}, p.errorf(tok, "%q is ambiguous, write %[2]dam, %[2]dpm or %02[2]d:00", tok.word, t.hour)
+	case t.hour > 23:
+		return naturalTime{}, p.errorf(tok, "%d is not an hour of the day", t.hour)
+	}
+	return t, nil
+}
+
+// parseHourRange reads the hours between two times on the hour
+func (p *naturalParser) parseHourRange(at naturalToken) error {
+	if p.schedule.hourRange != nil {
+		return p.errorf(at, "the hours are given twice")
+	}
+	start, err := p.parseTime()
+	if err != nil {
+		return err
+	}
+	if !p.accept("and", "to", "until", "till", "-") {
+		return p.errorf(p.token(), "expected the end of the hours")
+	}
+	endAt := p.token()
+	end, err := p.parseTime()
+	if err != nil {
+		return err
+	}
+	if start.minute != 0 || end.minute != 0 || start.second > 0 || end.second > 0 {
+		return p.errorf(at, "the hours must start and end on the hour")
+	}
+	if start.hour == end.hour {
+		return p.errorf(endAt, "the hours must not end when they start")
+	}
+	p.schedule.hourRange = []int{start.hour, (end.hour + 23) % 24}
+	p.schedule.hoursAt = at
+	return nil
+}
+
+// fields turns the schedule into the fields of a cron expression, with the
+// seconds field first when the schedule needs one
+func (s *naturalSchedule) fields(p *naturalParser) ([]string, error) {
+	second, minute, hour := "0", "0", "0"
+	hours := "*"
+	if s.hourRange != nil {
+		hours = strconv.Itoa(s.hourRange[0]) + "-" + strconv.Itoa(s.hourRange[1])
+	}
+	useSeconds := false
+
+	switch s.unit {
+	case secondUnit, minuteUnit:
+		if len(s.times) > 0 || s.pastMinute >= 0 {
+			return nil, p.errorf(s.timesAt, "a time cannot be given for a schedule that repeats within the hour, give the hours with between")
+		}
+		step := naturalStep(s.interval)
+		if 60%s.interval != 0 {
+			return nil, p.errorf(s.unitAt, "every %d %ss does not divide an hour evenly", s.interval, s.unitName())
+		}
+		hour = hours
+		if s.unit == secondUnit {
+			second, minute, useSeconds = step, "*", true
+		} else {
+			minute = step
+		}
+	case hourUnit:
+		if len(s.times) > 0 {
+			return nil, p.errorf(s.timesAt, "a time of day cannot be given for a schedule that repeats every hour, give the minute with at :30")
+		}
+		if 24%s.interval != 0 {
+			return nil, p.errorf(s.unitAt, "every %d hours does not divide a day evenly", s.interval)
+		}
+		if s.pastMinute >= 0 {
+			minute = strconv.Itoa(s.pastMinute)
+		}
+		hour = hours
+		if s.interval > 1 {
+			if s.hourRange == nil {
+				hours = "0-23"
+			}
+			hour = hours + "/" + strconv.Itoa(s.interval)
+		}
+	default:
+		switch {
+		case s.hourRange != nil:
+			return nil, p.errorf(s.unitAt, "a range of hours needs a schedule that repeats within it, i.e. every 15 minutes")
+		case s.pastMinute >= 0:
+			return nil, p.errorf(s.timesAt, "a minute past the hour needs a schedule that repeats every hour")
+		case s.interval > 1 && s.unit != monthUnit:
+			return nil, p.errorf(s.unitAt, "every %d %ss cannot be written as a schedule, the %[2]ss of a %s restart each %[3]s",
+				s.interval, s.unitName(), map[naturalUnit]string{dayUnit: "month", weekUnit: "month", yearUnit: "century"}[s.unit])
+		}
+		var err error
+		second, minute, hour, useSeconds, err = s.timeFields(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	day, month, weekday := "*", "*", "*"
+	if len(s.days) > 0 {
+		day = strings.Join(s.days, ",")
+	}
+	if len(s.weekdays) > 0 {
+		weekday = strings.Join(s.weekdays, ",")
+	}
+	if len(s.months) > 0 {
+		month = strings.Join(s.months, ",")
+	}
+	switch s.unit {
+	case weekUnit:
+		if len(s.days) > 0 {
+			return nil, p.errorf(s.daysAt, "a weekly schedule takes days of the week, not of the month")
+		}
+		if weekday == "*" {
+			weekday = "0"
+		}
+	case monthUnit:
+		if s.interval > 1 {
+			if 12%s.interval != 0 {
+				return nil, p.errorf(s.unitAt, "every %d months does not divide a year evenly", s.interval)
+			}
+			if len(s.months) > 0 {
+				return nil, p.errorf(s.unitAt, "the months are given twice")
+			}
+			month = "*/" + strconv.Itoa(s.interval)
+		}
+		if day == "*" && weekday == "*" {
+			day = "1"
+		}
+	case yearUnit:
+		if month == "*" {
+			month = "1"
+		}
+		if day == "*" && weekday == "*" {
+			day = "1"
+		}
+	}
+
+	fields := []string{minute, hour, day, month, weekday}
+	if useSeconds {
+		fields = append([]string{second}, fields...)
+	}
+	return fields, nil
+}
+
+// fieldAt returns the word of the phrase that a field of the schedule comes from
+func (s *naturalSchedule) fieldAt(field partType) naturalToken {
+	repeats := s.unit == secondUnit || s.unit == minuteUnit || s.unit == hourUnit
+	switch {
+	case field == day || field == weekday:
+		if len(s.days) > 0 || len(s.weekdays) > 0 {
+			return s.daysAt
+		}
+	case field == month:
+		if len(s.months) > 0 {
+			return s.monthsAt
+		}
+		if s.unit == monthUnit && s.interval > 1 {
+			return s.intervalAt
+		}
+	case field == hour && s.hourRange != nil:
+		return s.hoursAt
+	case field == hour && s.unit == hourUnit, field == minute && s.unit == minuteUnit, field == second && s.unit == secondUnit:
+		return s.intervalAt
+	case !repeats && len(s.times) > 0, field == minute && s.pastMinute >= 0:
+		return s.timesAt
+	}
+	return s.unitAt
+}
+
+// timeFields turns the times of day into the second, minute and hour fields, which
+// takes the times to share their minute and second, or their hour and second
+func (s *naturalSchedule) timeFields(p *naturalParser) (second, minute, hour string, useSeconds bool, err error) {
+	if len(s.times) == 0 {
+		return "0", "0", "0", false, nil
+	}
+	first := s.times[0]
+	sameMinute, sameHour := true, true
+	var hours, minutes []string
+	for _, t := range s.times {
+		useSeconds = useSeconds || t.second > 0
+		sameMinute = sameMinute && t.minute == first.minute && t.second == first.second
+		sameHour = sameHour && t.hour == first.hour && t.second == first.second
+		hours = append(hours, strconv.Itoa(t.hour))
+		minutes = append(minutes, strconv.Itoa(t.minute))
+	}
+	second = "0"
+	if first.second > 0 {
+		second = strconv.Itoa(first.second)
+	}
+	switch {
+	case sameMinute:
+		return second, strconv.Itoa(first.minute), strings.Join(hours, ","), useSeconds, nil
+	case sameHour:
+		return second, strings.Join(minutes, ","), strconv.Itoa(first.hour), useSeconds, nil
+	}
+	return "", "", "", false, p.errorf(s.timesAt, "times that differ in both the hour and the minute cannot be one schedule")
+}
+
+func (s *naturalSchedule) unitName() string {
+	return map[naturalUnit]string{
+		secondUnit: "second", minuteUnit: "minute", hourUnit: "hour", dayUnit: "day",
+		weekUnit: "week", monthUnit: "month", yearUnit: "year",
+	}[s.unit]
+}
+
+// naturalStep writes an interval as a cron step, or * for every value
+func naturalStep(interval int) string {
+	if interval == 1 {
+		return "*"
+	}
+	return "*/" + strconv.Itoa(interval)
+}
+
+func parseNaturalNumber(word string) (int, bool) {
+	if n, ok := naturalNumbers[word]; ok {
+		return n, true
+	}
+	n, err := strconv.Atoi(word)
+	return n, err == nil
+}
+
+// parseNaturalDay reads a day of the month written as an ordinal, i.e. 1st or 22nd
+func parseNaturalDay(word string) (int, bool) {
+	if len(word) < 3 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(word[:len(word)-2])
+	if err != nil || n < 1 || n > 31 {
+		return 0, false
+	}
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return n, word[len(word)-2:] == suffix
+}
+
+func isNaturalOrdinal(word string) bool {
+	_, ok := parseNaturalDay(word)
+	return ok || naturalOrdinals[word] > 0
+}
+
+// naturalWeekday reads a day of the week, which may be abbreviated or plural
+func naturalWeekday(word string) (int, bool) {
+	word = strings.TrimSuffix(word, "s")
+	for i, name := range []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"} {
+		if word == name || word == name[:3] || (len(word) >= 3 && strings.HasPrefix(name, word) && len(word) <= 5) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func isNaturalWeekday(word string) bool {
+	switch word {
+	case "weekday", "weekdays", "weekend", "weekends":
+		return true
+	}
+	first, _, _ := strings.Cut(word, "-")
+	_, ok := naturalWeekday(first)
+	return ok
+}
+
+// naturalMonth reads a month, which may be abbreviated
+func naturalMonth(word string) (int, bool) {
+	for i, name := range []string{"january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december"} {
+		if word == name || (len(word) >= 3 && strings.HasPrefix(name, word)) {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+func isNaturalMonth(word string) bool {
+	first, _, _ := strings.Cut(word, "-")
+	_, ok := naturalMonth(first)
+	return ok
+}
+
+// isNaturalTime reports whether the word starts with a digit, as times do
+func isNaturalTime(word string) bool {
+	return word != "" && word[0] >= '0' && word[0] <= '9' && !strings.HasSuffix(word, "st") &&
+		!strings.HasSuffix(word, "nd") && !strings.HasSuffix(word, "rd") && !strings.HasSuffix(word, "th")
+}
@@ -0,0 +1,162 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseNatural(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "every minute", want: "* * * * *"},
+		{text: "every 15 minutes", want: "*/15 * * * *"},
+		{text: "every fifteen minutes", want: "*/15 * * * *"},
+		{text: "every other minute", want: "*/2 * * * *"},
+		{text: "every half hour", want: "*/30 * * * *"},
+		{text: "every quarter hour", want: "*/15 * * * *"},
+		{text: "every 10 seconds", want: "*/10 * * * * *"},
+		{text: "every second", want: "* * * * * *"},
+		{text: "every hour", want: "0 * * * *"},
+		{text: "hourly", want: "0 * * * *"},
+		{text: "every 6 hours", want: "0 */6 * * *"},
+		{text: "every hour at :30", want: "30 * * * *"},
+		{text: "every hour at 15 minutes past the hour", want: "15 * * * *"},
+		{text: "hourly at half past", want: "30 * * * *"},
+		{text: "every 15 minutes between 9am and 5pm", want: "*/15 9-16 * * *"},
+		{text: "every 5 minutes from 10pm to 2am", want: "*/5 22-1 * * *"},
+		{text: "every 2 hours between 8am and 8pm on weekdays", want: "0 8,10,12,14,16,18 * * 1-5"},
+		{text: "every 2 hours between 9am and 5pm", want: "0 9,11,13,15 * * *"},
+		{text: "every 3 hours between 10am and 6pm", want: "0 10,13,16 * * *"},
+		{text: "every 4 hours between 1am and 11pm", want: "0 1,5,9,13,17,21 * * *"},
+		{text: "every day", want: "0 0 * * *"},
+		{text: "daily at 9am", want: "0 9 * * *"},
+		{text: "nightly at 11:30 pm", want: "30 23 * * *"},
+		{text: "every day at noon", want: "0 12 * * *"},
+		{text: "at midnight", want: "0 0 * * *"},
+		{text: "at 12am", want: "0 0 * * *"},
+		{text: "at 12pm", want: "0 12 * * *"},
+		{text: "at 17:45", want: "45 17 * * *"},
+		{text: "at 09:00", want: "0 9 * * *"},
+		{text: "at 9:30:15am", want: "15 30 9 * * *"},
+		{text: "at 9am and 5pm", want: "0 9,17 * * *"},
+		{text: "at 9am, 1pm and 5pm", want: "0 9,13,17 * * *"},
+		{text: "at 9:00 and 9:30", want: "*/30 9 * * *"},
+		{text: "weekdays at 9:30am", want: "30 9 * * 1-5"},
+		{text: "Every weekday at 9AM.", want: "0 9 * * 1-5"},
+		{text: "weekends at 10am", want: "0 10 * * 0,6"},
+		{text: "every Monday", want: "0 0 * * 1"},
+		{text: "on Mondays and Thursdays at 6pm", want: "0 18 * * 1,4"},
+		{text: "monday through friday at 8am", want: "0 8 * * 1-5"},
+		{text: "mon-fri at 8am", want: "0 8 * * 1-5"},
+		{text: "every tue, thu at 7:15", want: "15 7 * * 2,4"},
+		{text: "weekly", want: "0 0 * * 0"},
+		{text: "weekly on friday at 5pm", want: "0 17 * * 5"},
+		{text: "monthly", want: "0 0 1 * *"},
+		{text: "every month on the 15th", want: "0 0 15 * *"},
+		{text: "on the 1st and 15th at 6am", want: "0 6 1,15 * *"},
+		{text: "every 3 months", want: "0 0 1 */3 *"},
+		{text: "every other month on the 10th", want: "0 0 10 */2 *"},
+		{text: "first Monday of every month at noon", want: "0 12 * * 1#1"},
+		{text: "the last friday of the month", want: "0 0 * * 5L"},
+		{text: "the second and fourth tuesday of the month", want: "0 0 * * 2#2,2#4"},
+		{text: "last day of the month at 11pm", want: "0 23 L * *"},
+		{text: "the first day of every month", want: "0 0 1 * *"},
+		{text: "the first weekday of the month", want: "0 0 1W * *"},
+		{text: "on the 29th of february", want: "0 0 29 2 *"},
+		{text: "on the 31st of april through june", want: "0 0 31 4-6 *"},
+		{text: "the last weekday of the month at 5pm", want: "0 17 LW * *"},
+		{text: "the first monday of january", want: "0 0 * 1 1#1"},
+		{text: "yearly", want: "0 0 1 1 *"},
+		{text: "annually on july 4th", want: "0 0 4 7 *"},
+		{text: "every year on december 25 at 8am", want: "0 8 25 12 *"},
+		{text: "on january 1st and july 1st", want: "0 0 1 */6 *"},
+		{text: "every day in june through august at 6am", want: "0 6 * 6-8 *"},
+		{text: "every 10 minutes on weekdays in december", want: "*/10 * * 12 1-5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			cron, err := ParseNatural(tt.text)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, cron.String())
+			}
+		})
+	}
+}
+
+func TestParseNatural_Options(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(t, err) {
+		return
+	}
+	cron, err := ParseNatural("weekdays at 9am", WithLocation(loc), WithSeconds(), WithDayMatch(DayMatchOr))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "0 9 * * 1-5", cron.String())
+	next := cron.NextFrom(time.Date(2024, 1, 6, 0, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2024, 1, 8, 9, 0, 0, 0, loc), next)
+}
+
+func TestParseNatural_Error(t *testing.T) {
+	tests := []struct {
+		text   string
+		offset int
+		err    string
+	}{
+		// Ambiguous
+		{text: "at 9", offset: 3, err: `"9" is ambiguous, write 9am, 9pm or 09:00`},
+		{text: "daily at 5 and 9am", offset: 9, err: `"5" is ambiguous, write 5am, 5pm or 05:00`},
+		{text: "biweekly", offset: 0, err: "biweekly is ambiguous, it may mean twice a week or every other week"},
+		{text: "every second tuesday", offset: 6, err: "every second tuesday is ambiguous, it may mean every other tuesday or the second of the month"},
+		{text: "mondays on the 1st", offset: 15, err: "days of the month and days of the week together are ambiguous, they may mean either or both"},
+		// Unsupported
+		{text: "every 7 minutes", offset: 0, err: "every 7 minutes does not divide an hour evenly"},
+		{text: "every 5 hours", offset: 0, err: "every 5 hours does not divide a day evenly"},
+		{text: "every 5 months", offset: 0, err: "every 5 months does not divide a year evenly"},
+		{text: "every 3 days", offset: 0, err: "every 3 days cannot be written as a schedule, the days of a month restart each month"},
+		{text: "every other week", offset: 0, err: "every 2 weeks cannot be written as a schedule, the weeks of a month restart each month"},
+		{text: "fortnightly", offset: 0, err: "every other week cannot be written as a schedule, the weeks of a month restart each month"},
+		{text: "twice a day", offset: 0, err: "give the times instead, i.e. at 9am and 5pm"},
+		{text: "at 9am and 5:30pm", offset: 0, err: "times that differ in both the hour and the minute cannot be one schedule"},
+		{text: "every 15 minutes at 9am", offset: 17, err: "a time cannot be given for a schedule that repeats within the hour, give the hours with between"},
+		{text: "daily between 9am and 5pm", offset: 0, err: "a range of hours needs a schedule that repeats within it, i.e. every 15 minutes"},
+		{text: "every 15 minutes between 9:30am and 5pm", offset: 17, err: "the hours must start and end on the hour"},
+		{text: "the third weekday of the month", offset: 10, err: "only the first and last weekday of the month are supported"},
+		{text: "january 1st and july 4th", offset: 21, err: "different days in different months cannot be one schedule"},
+		{text: "the 15th monday", offset: 9, err: "a day of the week is counted with first to fifth or last"},
+		{text: "on the 31st of february", offset: 7, err: "the days are in none of the months, the schedule never activates"},
+		{text: "on february 30th", offset: 3, err: "the days are in none of the months, the schedule never activates"},
+		{text: "on the 31st of april and june at 9am", offset: 7, err: "the days are in none of the months, the schedule never activates"},
+		{text: "every 60 minutes", offset: 6, err: "60 is outside the range 0-59"},
+		{text: "every 24 hours on weekdays", offset: 6, err: "24 is outside the range 0-23"},
+		// Malformed
+		{text: "daily daily", offset: 6, err: "how often is given twice"},
+		{text: "at 9am at 5pm", offset: 7, err: "the time is given twice"},
+		{text: "at 13pm", offset: 3, err: "13 is not an hour of a 12-hour clock"},
+		{text: "at 25:00", offset: 3, err: "25 is not an hour of the day"},
+		{text: "at 9:5am", offset: 3, err: `expected a time such as 9am or 17:30, got "9:5am"`},
+		{text: "every 10", offset: 8, err: "expected a unit of time after 10"},
+		{text: "every blue moon", offset: 6, err: `unexpected "blue"`},
+		{text: "daily at", offset: 8, err: "expected a time"},
+		{text: "on the", offset: 6, err: "the phrase ends too soon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			_, err := ParseNatural(tt.text)
+			assert.ErrorIs(t, err, InvalidCronSchedule)
+
+			var parseErr *ParseError
+			if assert.ErrorAs(t, err, &parseErr) {
+				assert.Equal(t, tt.text, parseErr.Schedule)
+				assert.Equal(t, tt.offset, parseErr.Offset)
+				assert.EqualError(t, parseErr.Err, tt.err)
+			}
+		})
+	}
+
+	_, err := ParseNatural("  ")
+	assert.ErrorIs(t, err, EmptyCronSchedule)
+}
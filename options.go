@@ -0,0 +1,111 @@
+package cron
+
+import (
+	"math/rand"
+	"time"
+)
+
+/*
+Option configures how Parse reads a schedule and how the
+resulting Cron calculates its activations
+*/
+type Option func(c *config)
+
+// config is what the options are applied to, the Cron being parsed and the
+// settings that are only used while its schedule is parsed
+type config struct {
+	*Cron
+	// hashKey is the key the H fields of the schedule are derived from
+	hashKey string
+	// random is the source the ~ fields of the schedule are chosen from
+	random *rand.Rand
+}
+
+/*
+DayMatch decides how the day of the month and the day of the week
+are combined when deciding if a day is part of the schedule
+*/
+type DayMatch uint8
+
+const (
+	// DayMatchAnd requires both the day of the month and the day of the week to match
+	DayMatchAnd DayMatch = iota
+	// DayMatchOr requires either the day of the month or the day of the week to match,
+	// as in Vixie cron, unless either of them is *
+	DayMatchOr
+)
+
+/*
+WithLocation will set the cron schedule calculations to use the
+given location, by default UTC is used
+*/
+func WithLocation(loc *time.Location) Option {
+	return func(c *config) {
+		if loc == nil {
+			loc = time.UTC
+		}
+		c.loc = loc
+	}
+}
+
+/*
+WithSeconds will expect the schedule to begin with a seconds field [0-59],
+activations are then calculated to the second rather than the minute
+*/
+func WithSeconds() Option {
+	return func(c *config) {
+		c.seconds = true
+	}
+}
+
+/*
+WithYears will expect the schedule to end with a years field [1970-2199],
+the schedule never activates outside the years it is restricted to
+*/
+func WithYears() Option {
+	return func(c *config) {
+		c.years = true
+	}
+}
+
+/*
+WithDayMatch will set how the day of the month and day of the week
+are combined, by default DayMatchAnd is used
+*/
+func WithDayMatch(dayMatch DayMatch) Option {
+	return func(c *config) {
+		c.dayMatch = dayMatch
+	}
+}
+
+/*
+WithClock will set the clock the cron schedule reads the current time from,
+by default the SystemClock is used
+*/
+func WithClock(clock Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+/*
+WithHashKey will resolve the H fields of the schedule (H, H/15 and H(0-29)) to values
+derived from the key, i.e. the name of a job. The same key always gives the same
+values, while different keys spread across the field
+*/
+func WithHashKey(key string) Option {
+	return func(c *config) {
+		c.hashKey = key
+	}
+}
+
+/*
+WithRand will set the random source the ~ fields of the schedule (i.e. 0~30) are
+chosen from when it is parsed, by default the shared source of math/rand is used.
+A seeded source makes the chosen values reproducible, i.e. in tests
+*/
+func WithRand(random *rand.Rand) Option {
+	return func(c *config) {
+		c.random = random
+	}
+}
@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithSeconds(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, 6, 17, 18, 23, 10, 500, time.UTC))
+	cron, err := Parse("*/15 30 18 * * *", WithSeconds(), WithClock(clock))
+	assert.NoError(t, err)
+	assert.Equal(t, "*/15 30 18 * * *", cron.String())
+	assert.True(t, time.Date(2023, 6, 17, 18, 30, 0, 0, time.UTC).Equal(cron.Next()))
+	assert.True(t, time.Date(2023, 6, 16, 18, 30, 45, 0, time.UTC).Equal(cron.Prev()))
+	assert.False(t, cron.Now())
+
+	clock.Set(time.Date(2023, 6, 17, 18, 30, 45, 999, time.UTC))
+	assert.True(t, cron.Now())
+	assert.True(t, time.Date(2023, 6, 18, 18, 30, 0, 0, time.UTC).Equal(cron.Next()))
+	assert.True(t, time.Date(2023, 6, 17, 18, 30, 30, 0, time.UTC).Equal(cron.Prev()))
+
+	_, err = Parse("* * * * *", WithSeconds())
+	assert.ErrorIs(t, err, InvalidCronSchedule)
+	_, err = Parse("60 * * * * *", WithSeconds())
+	assert.ErrorIs(t, err, InvalidCronSchedule)
+}
+
+func TestWithLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	clock := NewFakeClock(time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC))
+	cron, err := Parse("0 9 * * *", WithLocation(newYork), WithClock(clock))
+	assert.NoError(t, err)
+
+	next := cron.Next()
+	assert.Equal(t, newYork, next.Location())
+	assert.True(t, time.Date(2023, 6, 18, 13, 0, 0, 0, time.UTC).Equal(next))
+
+	clock.Set(time.Date(2023, 6, 17, 13, 0, 0, 0, time.UTC))
+	assert.True(t, cron.Now())
+}
+
+func TestWithDayMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		dayMatch DayMatch
+		want     time.Time
+	}{
+		{
+			name:     "and - both restricted",
+			schedule: "0 0 13 * 5",
+			dayMatch: DayMatchAnd,
+			want:     time.Date(2023, 10, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "or - both restricted",
+			schedule: "0 0 13 * 5",
+			dayMatch: DayMatchOr,
+			want:     time.Date(2023, 6, 23, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "or - day of month is a wildcard",
+			schedule: "0 0 * * 1",
+			dayMatch: DayMatchOr,
+			want:     time.Date(2023, 6, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "or - day of week is a wildcard",
+			schedule: "0 0 1 * *",
+			dayMatch: DayMatchOr,
+			want:     time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "or - full range is not a wildcard",
+			schedule: "0 0 1 * 0-6",
+			dayMatch: DayMatchOr,
+			want:     time.Date(2023, 6, 18, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cron, err := Parse(tt.schedule, WithDayMatch(tt.dayMatch))
+			assert.NoError(t, err)
+			next := cron.NextFrom(time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC))
+			assert.True(t, tt.want.Equal(next), next)
+		})
+	}
+}
+
+func TestCron_ConcurrentUse(t *testing.T) {
+	clock := NewFakeClock(time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC))
+	cron, err := Parse("*/5 * * * *", WithClock(clock))
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = cron.Now()
+				_ = cron.Next()
+				_ = cron.Prev()
+				_ = cron.String()
+				clock.Advance(time.Minute)
+			}
+		}()
+	}
+	wg.Wait()
+}
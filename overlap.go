@@ -0,0 +1,240 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+	"time"
+)
+
+// hourMinutes is the set of the 60 minutes of an hour
+const hourMinutes = 1<<60 - 1
+
+/*
+Overlap is the analysis of a set of schedules over a window, made by AnalyzeOverlap
+*/
+type Overlap struct {
+	From time.Time
+	To   time.Time
+	// Minutes is the number of minutes in which each schedule activates
+	Minutes map[string]int
+	// Collisions are the minutes in which more than one schedule activates, in order
+	Collisions []Collision
+	// Peak is the greatest number of schedules that activate in the same minute,
+	// and PeakMinutes the minutes they do when more than one does
+	Peak        int
+	PeakMinutes []time.Time
+	// Pairs are every pair of the schedules, ordered by their names
+	Pairs []Pair
+}
+
+/*
+Collision is a minute in which more than one schedule activates, and the names of those that do
+*/
+type Collision struct {
+	Minute time.Time
+	Names  []string
+}
+
+/*
+Pair is how often two schedules activate in the same minute. Always is set when every
+activation of one of them collides with the other, i.e. every 15 minutes with every 5
+minutes, and Never when none do
+*/
+type Pair struct {
+	A      string
+	B      string
+	Shared int
+	Always bool
+	Never  bool
+}
+
+/*
+AnalyzeOverlap reports the minutes in which the named schedules activate together, from
+from until to, not including it. The days of each schedule are found in its own location,
+the activations within them from the minute and hour fields, without searching each minute.
+A schedule with seconds activates in a minute when any of its seconds in it does
+*/
+func AnalyzeOverlap(schedules map[string]*Cron, from, to time.Time) (*Overlap, error) {
+	if !to.After(from) {
+		return nil, errors.New("overlap window must end after it starts")
+	}
+	names := make([]string, 0, len(schedules))
+	for name, schedule := range schedules {
+		if schedule == nil {
+			return nil, fmt.Errorf("schedule %q is nil", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// The minutes each schedule activates in, by the UTC hour they are in
+	hours := map[int64]map[int]uint64{}
+	for i, name := range names {
+		schedules[name].minuteMasks(from, to, func(hour int64, mask uint64) {
+			if hours[hour] == nil {
+				hours[hour] = map[int]uint64{}
+			}
+			hours[hour][i] |= mask
+		})
+	}
+	keys := make([]int64, 0, len(hours))
+	for hour := range hours {
+		keys = append(keys, hour)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	overlap := &Overlap{From: from, To: to, Minutes: map[string]int{}}
+	minutes := make([]int, len(names))
+	shared := map[[2]int]int{}
+	for _, hour := range keys {
+		masks := hours[hour]
+		active := make([]int, 0, len(masks))
+		for i, mask := range masks {
+			active = append(active, i)
+			minutes[i] += bits.OnesCount64(mask)
+		}
+		sort.Ints(active)
+
+		var collide uint64
+		for a := 0; a < len(active); a++ {
+			for b := a + 1; b < len(active); b++ {
+				both := masks[active[a]] & masks[active[b]]
+				if both != 0 {
+					shared[[2]int{active[a], active[b]}] += bits.OnesCount64(both)
+					collide |= both
+				}
+			}
+		}
+		if len(active) > 0 && overlap.Peak == 0 {
+			overlap.Peak = 1
+		}
+		for ; collide != 0; collide &= collide - 1 {
+			minute := bits.TrailingZeros64(collide)
+			collision := Collision{Minute: time.Unix((hour+int64(minute))*60, 0).In(from.Location())}
+			for _, i := range active {
+				if masks[i]&(1<<minute) != 0 {
+					collision.Names = append(collision.Names, names[i])
+				}
+			}
+			overlap.Collisions = append(overlap.Collisions, collision)
+			if len(collision.Names) > overlap.Peak {
+				overlap.Peak = len(collision.Names)
+				overlap.PeakMinutes = nil
+			}
+			if len(collision.Names) == overlap.Peak {
+				overlap.PeakMinutes = append(overlap.PeakMinutes, collision.Minute)
+			}
+		}
+	}
+
+	for i, name := range names {
+		overlap.Minutes[name] = minutes[i]
+		for j := i + 1; j < len(names); j++ {
+			n := shared[[2]int{i, j}]
+			fewest := minutes[i]
+			if minutes[j] < fewest {
+				fewest = minutes[j]
+			}
+			overlap.Pairs = append(overlap.Pairs, Pair{
+				A:      name,
+				B:      names[j],
+				Shared: n,
+				Always: n > 0 && n == fewest,
+				Never:  n == 0,
+			})
+		}
+	}
+	return overlap, nil
+}
+
+// minuteMasks calls add with the minutes the schedule activates in from from until
+// to, as a bitset of the minutes of each UTC hour given as minutes since the epoch
+func (c *Cron) minuteMasks(from, to time.Time, add func(hour int64, mask uint64)) {
+	from, to = c.window(from, to)
+	start := from.In(c.loc)
+	for day := startOfDay(start.Year(), start.Month(), start.Day(), c.loc); day.Before(to); {
+		year, month, date := day.Date()
+		next := startOfDay(year, month, date+1, c.loc)
+		if (c.businessDays || c.year.contains(year) && c.month.contains(uint8(month))) && c.isDay(day) {
+			c.dayMinuteMasks(day, next, from, to, add)
+		}
+		day = next
+	}
+}
+
+// dayMinuteMasks adds the minutes of the hour and minute fields between the start
+// and end of a day, a segment at a time between daylight saving transitions so
+// that a wall clock hour that is skipped is left out and one that repeats is not
+func (c *Cron) dayMinuteMasks(day, end, from, to time.Time, add func(hour int64, mask uint64)) {
+	for segment := day; segment.Before(end); {
+		segmentEnd := end
+		if _, transition := segment.ZoneBounds(); !transition.IsZero() && transition.Before(end) {
+			segmentEnd = transition
+		}
+		first := segment.Hour()*60 + segment.Minute()
+		last := first + int(segmentEnd.Sub(segment)/time.Minute)
+
+		for hour := first / 60; hour*60 < last && hour < 24; hour++ {
+			if !c.hour.contains(uint8(hour)) {
+				continue
+			}
+			// The minutes of the hour that are within the segment
+			lo, hi := 0, 60
+			if first > hour*60 {
+				lo = first - hour*60
+			}
+			if last < hour*60+60 {
+				hi = last - hour*60
+			}
+			mask := c.minute.bits & (1<<hi - 1) &^ (1<<lo - 1)
+			start := segment.Add(time.Duration(hour*60-first) * time.Minute)
+			mask = c.clipMinutes(mask, start, from, to)
+			if mask == 0 {
+				continue
+			}
+
+			// Spread the minutes over the UTC hours they fall in
+			minute := start.Unix() / 60
+			shift := minute % 60
+			add(minute-shift, mask<<shift&hourMinutes)
+			if shift > 0 && mask>>(60-shift) != 0 {
+				add(minute-shift+60, mask>>(60-shift))
+			}
+		}
+		segment = segmentEnd
+	}
+}
+
+// window narrows from and to, to until but not including, to the NotBefore and NotAfter bounds
+func (c *Cron) window(from, to time.Time) (time.Time, time.Time) {
+	if from.Before(c.notBefore) {
+		from = c.notBefore
+	}
+	if !c.notAfter.IsZero() && to.After(c.notAfter) {
+		to = c.notAfter.Add(time.Nanosecond)
+	}
+	return from, to
+}
+
+// clipMinutes leaves out the minutes of the hour starting at start that have no
+// activation from from until to
+func (c *Cron) clipMinutes(mask uint64, start, from, to time.Time) uint64 {
+	if !start.Before(from) && !start.Add(time.Hour).After(to) {
+		return mask
+	}
+	for rest := mask; rest != 0; rest &= rest - 1 {
+		minute := bits.TrailingZeros64(rest)
+		minuteStart := start.Add(time.Duration(minute) * time.Minute)
+		within := false
+		for second := 0; second < 60 && !within; second++ {
+			t := minuteStart.Add(time.Duration(second) * time.Second)
+			within = c.second.contains(uint8(second)) && !t.Before(from) && t.Before(to)
+		}
+		if !within {
+			mask &^= 1 << minute
+		}
+	}
+	return mask
+}
@@ -0,0 +1,109 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeOverlap(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	overlap, err := AnalyzeOverlap(map[string]*Cron{
+		"backup":  MustParse("0 2 * * *"),
+		"cleanup": MustParse("*/30 * * * *"),
+		"report":  MustParse("0 2 * * 1"),
+		"sync":    MustParse("15 * * * *"),
+	}, from, from.AddDate(0, 0, 7))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, map[string]int{"backup": 7, "cleanup": 336, "report": 1, "sync": 168}, overlap.Minutes)
+	assert.Len(t, overlap.Collisions, 7)
+	assert.Equal(t, Collision{Minute: from.Add(2 * time.Hour), Names: []string{"backup", "cleanup", "report"}}, overlap.Collisions[0])
+	assert.Equal(t, Collision{Minute: from.AddDate(0, 0, 1).Add(2 * time.Hour), Names: []string{"backup", "cleanup"}}, overlap.Collisions[1])
+	assert.Equal(t, 3, overlap.Peak)
+	assert.Equal(t, []time.Time{from.Add(2 * time.Hour)}, overlap.PeakMinutes)
+
+	assert.Equal(t, []Pair{
+		{A: "backup", B: "cleanup", Shared: 7, Always: true},
+		{A: "backup", B: "report", Shared: 1, Always: true},
+		{A: "backup", B: "sync", Never: true},
+		{A: "cleanup", B: "report", Shared: 1, Always: true},
+		{A: "cleanup", B: "sync", Never: true},
+		{A: "report", B: "sync", Never: true},
+	}, overlap.Pairs)
+}
+
+// TestAnalyzeOverlap_Scan checks the analysis against the activations found one at
+// a time, across locations, both daylight saving transitions, seconds and bounds
+func TestAnalyzeOverlap_Scan(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(t, err) {
+		return
+	}
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, from := range []time.Time{
+		time.Date(2024, 3, 9, 12, 20, 30, 0, time.UTC),
+		time.Date(2024, 11, 2, 23, 59, 0, 0, time.UTC),
+	} {
+		to := from.Add(65 * time.Hour)
+		schedules := map[string]*Cron{
+			"night":   MustParse("*/10 1-3 * * *", WithLocation(newYork)),
+			"kolkata": MustParse("0,30 * * * *", WithLocation(kolkata)),
+			"seconds": MustParse("45 */20 * * * *", WithSeconds()),
+			"bounded": MustParse("*/5 * * * *", WithNotBefore(from.Add(12*time.Hour)), WithNotAfter(from.Add(36*time.Hour))),
+			"sunday":  MustParse("0 * * * 0", WithLocation(newYork)),
+		}
+		overlap, err := AnalyzeOverlap(schedules, from, to)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		minutes := map[time.Time][]string{}
+		for name, schedule := range schedules {
+			seen := map[time.Time]bool{}
+			for next := schedule.NextFrom(from.Add(-time.Nanosecond)); !next.IsZero() && next.Before(to); next = schedule.NextFrom(next) {
+				minute := next.Truncate(time.Minute).UTC()
+				if !seen[minute] {
+					seen[minute] = true
+					minutes[minute] = append(minutes[minute], name)
+				}
+			}
+			assert.Equal(t, len(seen), overlap.Minutes[name], name)
+		}
+		var want []Collision
+		for minute, names := range minutes {
+			if len(names) > 1 {
+				sort.Strings(names)
+				want = append(want, Collision{Minute: minute, Names: names})
+			}
+		}
+		sort.Slice(want, func(i, j int) bool { return want[i].Minute.Before(want[j].Minute) })
+		assert.NotEmpty(t, want)
+		assert.Equal(t, want, overlap.Collisions)
+	}
+}
+
+func TestAnalyzeOverlap_Empty(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	overlap, err := AnalyzeOverlap(map[string]*Cron{"leap": MustParse("0 0 29 2 *")}, from, from.AddDate(0, 1, 0))
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, overlap.Peak)
+		assert.Empty(t, overlap.Collisions)
+		assert.Empty(t, overlap.Pairs)
+		assert.Equal(t, map[string]int{"leap": 0}, overlap.Minutes)
+	}
+}
+
+func TestAnalyzeOverlap_Error(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := AnalyzeOverlap(map[string]*Cron{"a": MustParse("* * * * *")}, from, from)
+	assert.EqualError(t, err, "overlap window must end after it starts")
+	_, err = AnalyzeOverlap(map[string]*Cron{"a": nil}, from, from.Add(time.Hour))
+	assert.EqualError(t, err, `schedule "a" is nil`)
+}
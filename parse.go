@@ -2,26 +2,78 @@ package cron
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 )
 
 type partType string
 
 const (
+	second  partType = "second"
 	minute  partType = "minute"
 	hour    partType = "hour"
 	day     partType = "day"
 	month   partType = "month"
 	weekday partType = "weekday"
+	year    partType = "year"
 )
 
+// maxFields is the most fields any schedule has: seconds, the five standard fields and years
+const maxFields = 7
+
+var (
+	monthNames   = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	weekdayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+// dialect describes the syntax a schedule is written in. It only affects
+// parsing, the resulting Cron is the same whichever syntax was used.
+type dialect struct {
+	// weekdayBase is the number used for Sunday, 0 in standard cron and 1 in Quartz
+	weekdayBase uint8
+	// sundaySeven accepts 7 as well as 0 for Sunday in the day of the week field
+	sundaySeven bool
+	// lastYear is the last year the years field accepts, maxYear when zero
+	lastYear int
+	// noWrap rejects ranges that wrap around the end of their field (i.e. 22-2)
+	noWrap bool
+}
+
+// macros are the schedules the @ macros of Vixie cron stand for
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var standard = dialect{
+	sundaySeven: true,
+}
+
+/*
+MustParse is like Parse but panics with the *ParseError if the schedule
+cannot be parsed, it simplifies initializing package-level schedules
+*/
+func MustParse(schedule string, opts ...Option) *Cron {
+	cron, err := Parse(schedule, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return cron
+}
+
 /*
 Parse takes a standard cron schedule (* * * * *) and returns
 a Cron object if the schedule is valid; otherwise, it returns an error.
-It uses goroutines to parse each part of the schedule concurrently, resulting
-in faster parsing.
+The parts of the schedule are tokenized in a single pass without
+splitting the string, so parsing does not allocate beyond the returned Cron.
+Options may be given to change how the schedule is read and calculated.
 
 The schedule follows the standard format:
 
@@ -29,171 +81,479 @@ The schedule follows the standard format:
 
 * [0-23] (* , / -)
 
-* [1-31] (* , / -)
+* [1-31] (* , / -)    (? L W)
+
+* [1-12] (* , / -)    JAN-DEC
+
+* [0-7]  (* , / -)    SUN-SAT (? L #)
 
-* [1-12] (* , / -)
+The macros @yearly (or @annually), @monthly, @weekly, @daily (or @midnight)
+and @hourly may stand in for the whole schedule.
 
-* [0-6]  (* , / -)
+A step counts from the start of its range, 1-10/3 is 1, 4, 7 and 10, and a step
+from a single value runs to the end of the field, 5/15 is 5, 20, 35 and 50.
+Sunday is either 0 or 7 in the day of the week field.
+
+With WithSeconds, the schedule begins with an additional [0-59] seconds field,
+and with WithYears it ends with an additional [1970-2199] years field.
+
+The day fields accept ? in place of *, and the Quartz extensions: L for the last
+day of the month, L-3 for three days before it, 15W for the weekday nearest the
+15th, LW for the last weekday of the month, 5L for the last Friday of the month
+and 5#3 for the third Friday of the month.
+
+A range may wrap around the end of its field, 22-2 is 10pm through 2am and
+FRI-MON is Friday through Monday, and a step over it counts from its start too.
+
+With WithHashKey, any field but the years may use H for a value derived from
+the key: H alone, H/15 for every 15 from a derived start, and H(0-29) for a
+value within a range. H picks days of the month from 1-28 only.
+
+As in OpenBSD cron, 0~30 stands for a random value between 0 and 30 that is
+chosen once, when the schedule is parsed. Either bound may be left out for the
+bound of the field, and 0~59/15 steps from a random value in 0-14. WithRand
+gives the random source.
 */
-func Parse(schedule string) (*Cron, error) {
+func Parse(schedule string, opts ...Option) (*Cron, error) {
 	// If schedule is empty, return error
 	if schedule == "" {
 		return nil, EmptyCronSchedule
 	}
 
-	cronParts := strings.Split(schedule, " ")
-	// If the length of all the parts after splitting is not 5, return error
-	if len(cronParts) != 5 {
-		return nil, InvalidCronSchedule
+	conf := newConfig(opts)
+	cron := conf.Cron
+
+	// A macro (i.e. @daily) stands for a whole schedule
+	if strings.HasPrefix(schedule, "@") {
+		return parseMacro(conf, schedule)
 	}
 
-	var wg sync.WaitGroup
-	errCh := make(chan error, 5)
-	cron := &Cron{
-		utc: true,
+	// If the schedule does not have exactly the expected parts, return error
+	parts := cron.fieldCount()
+	if strings.Count(schedule, " ") != parts-1 {
+		return nil, &ParseError{
+			Schedule: schedule,
+			Err:      fmt.Errorf("expected %d fields separated by single spaces", parts),
+		}
 	}
 
-	// Using sync.WaitGroup, we can parse the 5 parts independently and concurrently
-	for i := 0; i < 5; i++ {
-		wg.Add(1)
-		go func(i int) {
-			defer wg.Done()
-			var err error
-			switch i {
-			case 0:
-				cron.minute, err = parseCronPart(cronParts[i], 0, 59, minute)
-			case 1:
-				cron.hour, err = parseCronPart(cronParts[i], 0, 23, hour)
-			case 2:
-				cron.day, err = parseCronPart(cronParts[i], 1, 31, day)
-			case 3:
-				cron.month, err = parseCronPart(cronParts[i], 1, 12, month)
-			case 4:
-				cron.weekday, err = parseCronPart(cronParts[i], 0, 6, weekday)
-			}
-			if err != nil {
-				errCh <- err
-			}
-		}(i)
+	var cronParts [maxFields]string
+	var offsets [maxFields]int
+	rest := schedule
+	for i := 0; i < parts; i++ {
+		offsets[i] = len(schedule) - len(rest)
+		cronParts[i], rest, _ = strings.Cut(rest, " ")
+	}
+
+	if err := standard.parseFields(conf, schedule, cronParts[:parts], offsets[:parts]); err != nil {
+		return nil, err
 	}
-	wg.Wait()
+	return cron, nil
+}
 
-	close(errCh)
+// parseMacro parses the schedule a macro stands for, with the
+// seconds and years fields when cron uses them
+func parseMacro(cron *config, macro string) (*Cron, error) {
+	expanded, ok := macros[macro]
+	if !ok {
+		return nil, &ParseError{Schedule: macro, Err: fmt.Errorf("unknown macro %q", macro)}
+	}
 
-	for err := range errCh {
+	cronParts := make([]string, 0, maxFields)
+	if cron.seconds {
+		cronParts = append(cronParts, "0")
+	}
+	cronParts = append(cronParts, strings.Fields(expanded)...)
+	if cron.years {
+		cronParts = append(cronParts, "*")
+	}
+
+	if err := standard.parseFields(cron, macro, cronParts, make([]int, len(cronParts))); err != nil {
+		return nil, err
+	}
+	return cron.Cron, nil
+}
+
+// splitFields separates a schedule on runs of spaces and tabs, recording each field and
+// its offset. It returns the number of fields found, which may exceed maxFields.
+func splitFields(schedule string, cronParts *[maxFields]string, offsets *[maxFields]int) int {
+	count := 0
+	for i := 0; i < len(schedule); {
+		if schedule[i] == ' ' || schedule[i] == '\t' {
+			i++
+			continue
+		}
+		end := i
+		for end < len(schedule) && schedule[end] != ' ' && schedule[end] != '\t' {
+			end++
+		}
+		if count < maxFields {
+			cronParts[count] = schedule[i:end]
+			offsets[count] = i
+		}
+		count++
+		i = end
+	}
+	return count
+}
+
+// newConfig returns a config with the options applied, its Cron ready for its fields to be parsed
+func newConfig(opts []Option) *config {
+	conf := &config{
+		Cron: &Cron{
+			loc: time.UTC,
+		},
+	}
+	for _, opt := range opts {
+		opt(conf)
+	}
+	return conf
+}
+
+// newCron returns a Cron with the options applied, for where no schedule is parsed
+func newCron(opts []Option) *Cron {
+	return newConfig(opts).Cron
+}
+
+// fieldCount is the number of fields expected in the schedule
+func (c *Cron) fieldCount() int {
+	parts := 5
+	if c.seconds {
+		parts++
+	}
+	if c.years {
+		parts++
+	}
+	return parts
+}
+
+// parseFields parses each of the separated fields of a schedule into cron,
+// the optional seconds and years fields are expected when cron uses them
+func (d dialect) parseFields(cron *config, schedule string, cronParts []string, offsets []int) error {
+	if !cron.seconds {
+		cron.second = newSet[uint8](0)
+	}
+
+	var err error
+	for i, cronPart := range cronParts {
+		// Line the field up with the seconds field, whether or not it is used
+		field := i
+		if !cron.seconds {
+			field++
+		}
+
+		var part partType
+		switch field {
+		case 0:
+			part = second
+			cron.second, err = d.parseCronPart(cron, cronPart, 0, 59, part)
+		case 1:
+			part = minute
+			cron.minute, err = d.parseCronPart(cron, cronPart, 0, 59, part)
+		case 2:
+			part = hour
+			cron.hour, err = d.parseCronPart(cron, cronPart, 0, 23, part)
+		case 3:
+			part = day
+			cron.day, err = d.parseCronPart(cron, cronPart, 1, 31, part)
+			cron.dayStar = cronPart == "*" || cronPart == "?"
+		case 4:
+			part = month
+			cron.month, err = d.parseCronPart(cron, cronPart, 1, 12, part)
+		case 5:
+			part = weekday
+			cron.weekday, err = d.parseCronPart(cron, cronPart, d.weekdayBase, d.weekdayBase+6, part)
+			cron.weekdayStar = cronPart == "*" || cronPart == "?"
+		case 6:
+			part = year
+			cron.year, err = d.parseYearPart(cronPart)
+		}
 		if err != nil {
-			return nil, errors.Join(InvalidCronSchedule, err)
+			return &ParseError{Schedule: schedule, Field: string(part), Offset: offsets[i], Err: err}
 		}
 	}
-	return cron, nil
+	return nil
 }
 
 // parseCronPart does all the heavy lifting of turning a cron part
-// into an set of values to use in the Cron struct
-func parseCronPart(cronPart string, min, max uint8, part partType) (set[uint8], error) {
+// into an set of values to use in the Cron struct. The L, W and #
+// rules of the day fields are recorded on the cron directly.
+func (d dialect) parseCronPart(cron *config, cronPart string, min, max uint8, part partType) (set[uint8], error) {
 	var err error
-	var offset uint8 = 0
-	// day & month start with 1 instead of 0
-	if part == day || part == month {
-		offset = 1
-	}
+	timeSet := newSet[uint8]()
 
-	timeSet := newSet[uint8](int(max))
+	// Values may go one past Saturday for Sunday, while * and the rules keep to the field
+	valueMax := max
+	if part == weekday && d.sundaySeven {
+		valueMax = max + 1
+	}
 
 	// Simple Validation for empty cron part
 	if cronPart == "" {
 		return timeSet, InvalidCronSchedule
 	}
 
-	// Easiest case, if the cron part is only '*' that means get all values for that part
-	if cronPart == "*" {
-		timeSet.add(rangeSlice(min, max, 1, offset)...)
-		return timeSet, nil
+	// Easiest case, if the cron part is only '*' that means get all values for that part.
+	// The day fields may use '?' to the same effect
+	if cronPart == "*" || (cronPart == "?" && (part == day || part == weekday)) {
+		timeSet.addRange(min, max, 1, min)
+		return d.weekdays(timeSet, part), nil
 	}
 
-	// 1. Find & Split cron part list components, these are independent of each other
-	list := strings.Split(cronPart, ",")
+	// 1. Cycle through the list components, these are independent of each other
+	for more := true; more; {
+		var item string
+		item, cronPart, more = strings.Cut(cronPart, ",")
 
-	// 2. Cycle through the list components
-	for _, item := range list {
+		// 2. The day fields have rules of their own (i.e. L, 15W, 5#3)
+		if part == day || part == weekday {
+			var ok bool
+			ok, err = d.parseDayRule(cron.Cron, item, min, max, part)
+			if err != nil {
+				return set[uint8]{}, err
+			}
+			if ok {
+				continue
+			}
+		}
 
 		// 3. Find and split Step Components
-		steps := strings.Split(item, "/")
+		base, stepPart, hasStep := strings.Cut(item, "/")
 		step := uint8(1)
 
 		// 4. If part is a step component, save in step
-		if len(steps) == 2 {
-			step, err = aToi8(steps[1], min, max)
+		if hasStep {
+			step, err = aToi8(stepPart, min, max)
 			if err != nil {
 				return set[uint8]{}, err
 			}
+			if step == 0 {
+				return set[uint8]{}, errors.New("step must be greater than zero")
+			}
 		}
-		// 5. If first part of split is * (i.e. */5) then we can create range slice and continue
-		if steps[0] == "*" {
-			timeSet.add(rangeSlice(min, max, step, offset)...)
+		// 5. If first part of split is * (i.e. */5) then we can add the stepped range and continue
+		if base == "*" {
+			timeSet.addRange(min, max, step, min)
+			continue
+		}
+		// 5a. An H stands for values derived from the hash key (i.e. H/15)
+		if strings.HasPrefix(base, "H") {
+			var hashed set[uint8]
+			hashed, err = d.parseHash(cron, base, step, hasStep, min, max, part)
+			if err != nil {
+				return set[uint8]{}, err
+			}
+			timeSet.bits |= hashed.bits
+			continue
+		}
+		// 5b. A ~ stands for a random value chosen once, while parsing (i.e. 0~30)
+		if strings.Contains(base, "~") {
+			var random set[uint8]
+			random, err = d.parseRandom(cron, base, step, hasStep, min, max, part)
+			if err != nil {
+				return set[uint8]{}, err
+			}
+			timeSet.bits |= random.bits
 			continue
 		}
 
 		// 6. Find and split range component
-		ranges := strings.Split(steps[0], "-")
+		lower, upper, isRange := strings.Cut(base, "-")
 		var toi8, localMin, localMax uint8
 
 		// 7. If part is a range component, find local min/max of the component,
-		// validate, and create range slice using the saved step from earlier
-		if len(ranges) == 2 {
-			localMin, err = aToi8(ranges[0], min, max)
+		// validate, and add the range using the saved step from earlier
+		if isRange {
+			localMin, err = parseValue(lower, min, valueMax, part)
 			if err != nil {
 				return set[uint8]{}, err
 			}
-			localMax, err = aToi8(ranges[1], min, max)
+			localMax, err = parseValue(upper, min, valueMax, part)
 			if err != nil {
 				return set[uint8]{}, err
 			}
-			if localMin > localMax {
+			items := newSet[uint8]()
+			switch {
+			case localMin > localMax && d.noWrap:
 				return set[uint8]{}, errors.New("range min cannot be greater than range max")
+			case localMin > localMax:
+				// A range that wraps around the end of the field (i.e. 22-2) steps from its start
+				span := int(max-min) + 1
+				length := int(localMax) + span - int(localMin)
+				for offset := 0; offset <= length; offset += int(step) {
+					items.add(min + uint8((int(localMin-min)+offset)%span))
+				}
+			default:
+				// A step counts from the start of the range, as in Vixie cron (i.e. 1-10/3 is 1,4,7,10)
+				items.addRange(localMin, localMax, step, localMin)
 			}
-			timeSet.add(rangeSlice(localMin, localMax, step, offset)...)
+			timeSet.bits |= items.bits
 			continue
 		}
 
-		// 8. If part is simply an integer, convert to uint8 and add to timeSet
-		toi8, err = aToi8(ranges[0], min, max)
+		// 8. If part is simply a value, convert to uint8 and add to timeSet,
+		// or step from it until the end of the field (i.e. 5/15)
+		toi8, err = parseValue(lower, min, valueMax, part)
 		if err != nil {
 			return set[uint8]{}, err
 		}
+		if hasStep {
+			end := max
+			if toi8 > end {
+				end = toi8
+			}
+			timeSet.addRange(toi8, end, step, toi8)
+			continue
+		}
 		timeSet.add(toi8)
 	}
 
-	return timeSet, nil
+	return d.weekdays(timeSet, part), nil
 }
 
-// rangeSlice takes a start, end, step, and offset value to
-// create a slice of uint8
-func rangeSlice(start, end, step, offset uint8) []uint8 {
-	// Add the offset
-	start = start - offset
-	end = end - offset
+// weekdays moves a set of weekdays numbered from the dialect's weekdayBase to start from Sunday as 0
+func (d dialect) weekdays(timeSet set[uint8], part partType) set[uint8] {
+	if part != weekday {
+		return timeSet
+	}
+	if d.sundaySeven && timeSet.contains(7) {
+		timeSet.bits &^= 1 << 7
+		timeSet.add(0)
+	}
+	timeSet.bits >>= d.weekdayBase
+	return timeSet
+}
 
-	// Find start & end divisible by step
-	{
-		// uint8 LTE to end that is div by step
-		end = (end - (end % step)) / step
+// parseDayRule records the L, W and # rules of the day fields on cron, it
+// reports whether item was such a rule
+func (d dialect) parseDayRule(cron *Cron, item string, min, max uint8, part partType) (bool, error) {
+	if part == day {
+		switch {
+		case item == "L":
+			cron.lastDays.add(0)
+			return true, nil
+		case item == "LW":
+			cron.lastWeekday = true
+			return true, nil
+		case strings.HasPrefix(item, "L-"):
+			// Days before the last day of the month, at most a month of them
+			before, err := aToi8(item[2:], 0, max-min)
+			if err != nil {
+				return false, err
+			}
+			cron.lastDays.add(before)
+			return true, nil
+		case strings.HasSuffix(item, "W"):
+			nearest, err := aToi8(item[:len(item)-1], min, max)
+			if err != nil {
+				return false, err
+			}
+			cron.nearestWeekdays.add(nearest)
+			return true, nil
+		}
+		return false, nil
+	}
 
-		// uint8 GTE to start that is div by step
-		startRem := (start + step) % step
-		if startRem != 0 {
-			start = start + step - startRem
+	switch {
+	case len(item) > 1 && strings.HasSuffix(item, "L"):
+		last, err := parseValue(item[:len(item)-1], min, max, part)
+		if err != nil {
+			return false, err
+		}
+		cron.lastWeekdays.add(last - d.weekdayBase)
+		return true, nil
+	}
+	if value, nth, ok := strings.Cut(item, "#"); ok {
+		weekday, err := parseValue(value, min, max, part)
+		if err != nil {
+			return false, err
+		}
+		n, err := aToi8(nth, 1, 5)
+		if err != nil {
+			return false, err
 		}
-		start = start / step
+		cron.nthWeekdays.add((n-1)*7 + weekday - d.weekdayBase)
+		return true, nil
 	}
+	return false, nil
+}
 
-	// Able to calculate worst-case for the capacity of range slice
-	result := make([]uint8, 0, end-start+1)
-	for i := start; i <= end; i++ {
-		result = append(result, (i*step)+offset)
+// parseYearPart turns a cron part of the years field into a yearSet,
+// steps always count from the start of their range
+func (d dialect) parseYearPart(cronPart string) (yearSet, error) {
+	lastYear := d.lastYear
+	if lastYear == 0 {
+		lastYear = maxYear
 	}
 
-	return result
+	years := yearSet{}
+	if cronPart == "" {
+		return years, InvalidCronSchedule
+	}
+	if cronPart == "*" {
+		return years, nil
+	}
+
+	for more := true; more; {
+		var item string
+		item, cronPart, more = strings.Cut(cronPart, ",")
+
+		base, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = aToYear(stepPart, 1, lastYear-minYear)
+			if err != nil {
+				return yearSet{}, err
+			}
+		}
+
+		start, end := minYear, lastYear
+		if base != "*" {
+			lower, upper, isRange := strings.Cut(base, "-")
+			var err error
+			start, err = aToYear(lower, minYear, lastYear)
+			if err != nil {
+				return yearSet{}, err
+			}
+			end = start
+			if isRange {
+				end, err = aToYear(upper, minYear, lastYear)
+				if err != nil {
+					return yearSet{}, err
+				}
+				if start > end {
+					return yearSet{}, errors.New("range min cannot be greater than range max")
+				}
+			} else if hasStep {
+				end = lastYear
+			}
+		}
+		years.addRange(start, end, step)
+	}
+
+	return years, nil
+}
+
+// parseValue converts a value of a field into uint8 with validation, months
+// and weekdays may also be given by their three letter names, and L on its
+// own is the last day of the week
+func parseValue(a string, min, max uint8, part partType) (uint8, error) {
+	if a == "L" && part == weekday {
+		return min + 6, nil
+	}
+	if len(a) == 3 && (part == month || part == weekday) {
+		names, first := monthNames, uint8(1)
+		if part == weekday {
+			names, first = weekdayNames, min
+		}
+		for i, name := range names {
+			if strings.EqualFold(a, name) {
+				return first + uint8(i), nil
+			}
+		}
+	}
+	return aToi8(a, min, max)
 }
 
 // aToi8 attempts to convert a string into uint8 with vaidation
@@ -204,7 +564,20 @@ func aToi8(a string, min, max uint8) (uint8, error) {
 	}
 	val := uint8(parsed)
 	if val < min || val > max {
-		return 0, InvalidCronSchedule
+		return 0, fmt.Errorf("%d is outside the range %d-%d", val, min, max)
+	}
+	return val, nil
+}
+
+// aToYear attempts to convert a string into a year with validation
+func aToYear(a string, min, max int) (int, error) {
+	parsed, err := strconv.ParseUint(a, 10, 16)
+	if err != nil {
+		return 0, err
+	}
+	val := int(parsed)
+	if val < min || val > max {
+		return 0, fmt.Errorf("%d is outside the range %d-%d", val, min, max)
 	}
 	return val, nil
 }
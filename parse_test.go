@@ -1,8 +1,12 @@
 package cron
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestParse(t *testing.T) {
@@ -16,12 +20,16 @@ func TestParse(t *testing.T) {
 			name:     "base cron",
 			schedule: "* * * * *",
 			want: &Cron{
-				minute:  newSet[uint8](60, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59),
-				hour:    newSet[uint8](60, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23),
-				day:     newSet[uint8](60, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31),
-				month:   newSet[uint8](60, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
-				weekday: newSet[uint8](60, 0, 1, 2, 3, 4, 5, 6),
-				utc:     true,
+				minute:  newSet[uint8](0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59),
+				hour:    newSet[uint8](0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23),
+				day:     newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31),
+				month:   newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
+				weekday: newSet[uint8](0, 1, 2, 3, 4, 5, 6),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
+
+				dayStar:     true,
+				weekdayStar: true,
 			},
 			wantErr: false,
 		},
@@ -29,12 +37,13 @@ func TestParse(t *testing.T) {
 			name:     "single digit cron",
 			schedule: "1 1 1 1 1",
 			want: &Cron{
-				minute:  newSet[uint8](60, 1),
-				hour:    newSet[uint8](60, 1),
-				day:     newSet[uint8](60, 1),
-				month:   newSet[uint8](60, 1),
-				weekday: newSet[uint8](60, 1),
-				utc:     true,
+				minute:  newSet[uint8](1),
+				hour:    newSet[uint8](1),
+				day:     newSet[uint8](1),
+				month:   newSet[uint8](1),
+				weekday: newSet[uint8](1),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
 			},
 			wantErr: false,
 		},
@@ -42,12 +51,15 @@ func TestParse(t *testing.T) {
 			name:     "double digit cron",
 			schedule: "12 12 12 12 *",
 			want: &Cron{
-				minute:  newSet[uint8](60, 12),
-				hour:    newSet[uint8](60, 12),
-				day:     newSet[uint8](60, 12),
-				month:   newSet[uint8](60, 12),
-				weekday: newSet[uint8](60, 0, 1, 2, 3, 4, 5, 6),
-				utc:     true,
+				minute:  newSet[uint8](12),
+				hour:    newSet[uint8](12),
+				day:     newSet[uint8](12),
+				month:   newSet[uint8](12),
+				weekday: newSet[uint8](0, 1, 2, 3, 4, 5, 6),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
+
+				weekdayStar: true,
 			},
 			wantErr: false,
 		},
@@ -55,12 +67,13 @@ func TestParse(t *testing.T) {
 			name:     "simple list cron",
 			schedule: "1,12 1,12 1,12 1,12 1,2",
 			want: &Cron{
-				minute:  newSet[uint8](60, 1, 12),
-				hour:    newSet[uint8](60, 1, 12),
-				day:     newSet[uint8](60, 1, 12),
-				month:   newSet[uint8](60, 1, 12),
-				weekday: newSet[uint8](60, 1, 2),
-				utc:     true,
+				minute:  newSet[uint8](1, 12),
+				hour:    newSet[uint8](1, 12),
+				day:     newSet[uint8](1, 12),
+				month:   newSet[uint8](1, 12),
+				weekday: newSet[uint8](1, 2),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
 			},
 			wantErr: false,
 		},
@@ -68,12 +81,13 @@ func TestParse(t *testing.T) {
 			name:     "simple step cron",
 			schedule: "*/5 */5 */5 */5 */5",
 			want: &Cron{
-				minute:  newSet[uint8](60, 0, 5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55),
-				hour:    newSet[uint8](60, 0, 5, 10, 15, 20),
-				day:     newSet[uint8](60, 1, 6, 11, 16, 21, 26, 31),
-				month:   newSet[uint8](60, 1, 6, 11),
-				weekday: newSet[uint8](60, 0, 5),
-				utc:     true,
+				minute:  newSet[uint8](0, 5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55),
+				hour:    newSet[uint8](0, 5, 10, 15, 20),
+				day:     newSet[uint8](1, 6, 11, 16, 21, 26, 31),
+				month:   newSet[uint8](1, 6, 11),
+				weekday: newSet[uint8](0, 5),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
 			},
 			wantErr: false,
 		},
@@ -81,12 +95,13 @@ func TestParse(t *testing.T) {
 			name:     "simple range cron",
 			schedule: "1-4 1-4 1-4 1-4 1-4",
 			want: &Cron{
-				minute:  newSet[uint8](60, 1, 2, 3, 4),
-				hour:    newSet[uint8](60, 1, 2, 3, 4),
-				day:     newSet[uint8](60, 1, 2, 3, 4),
-				month:   newSet[uint8](60, 1, 2, 3, 4),
-				weekday: newSet[uint8](60, 1, 2, 3, 4),
-				utc:     true,
+				minute:  newSet[uint8](1, 2, 3, 4),
+				hour:    newSet[uint8](1, 2, 3, 4),
+				day:     newSet[uint8](1, 2, 3, 4),
+				month:   newSet[uint8](1, 2, 3, 4),
+				weekday: newSet[uint8](1, 2, 3, 4),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
 			},
 			wantErr: false,
 		},
@@ -94,12 +109,13 @@ func TestParse(t *testing.T) {
 			name:     "range with step cron",
 			schedule: "1-4/2 1-4/2 1-4/2 1-4/2 1-4/2",
 			want: &Cron{
-				minute:  newSet[uint8](60, 2, 4),
-				hour:    newSet[uint8](60, 2, 4),
-				day:     newSet[uint8](60, 1, 3),
-				month:   newSet[uint8](60, 1, 3),
-				weekday: newSet[uint8](60, 2, 4),
-				utc:     true,
+				minute:  newSet[uint8](1, 3),
+				hour:    newSet[uint8](1, 3),
+				day:     newSet[uint8](1, 3),
+				month:   newSet[uint8](1, 3),
+				weekday: newSet[uint8](1, 3),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
 			},
 			wantErr: false,
 		},
@@ -107,12 +123,13 @@ func TestParse(t *testing.T) {
 			name:     "lists with range with step cron",
 			schedule: "1-2,*/5 1-2,*/5 1-2,*/5 1-2,*/5 1-2,*/5",
 			want: &Cron{
-				minute:  newSet[uint8](60, 0, 1, 2, 5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55),
-				hour:    newSet[uint8](60, 0, 1, 2, 5, 10, 15, 20),
-				day:     newSet[uint8](60, 1, 2, 6, 11, 16, 21, 26, 31),
-				month:   newSet[uint8](60, 1, 2, 6, 11),
-				weekday: newSet[uint8](60, 0, 1, 2, 5),
-				utc:     true,
+				minute:  newSet[uint8](0, 1, 2, 5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55),
+				hour:    newSet[uint8](0, 1, 2, 5, 10, 15, 20),
+				day:     newSet[uint8](1, 2, 6, 11, 16, 21, 26, 31),
+				month:   newSet[uint8](1, 2, 6, 11),
+				weekday: newSet[uint8](0, 1, 2, 5),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
 			},
 			wantErr: false,
 		},
@@ -120,12 +137,13 @@ func TestParse(t *testing.T) {
 			name:     "lists with range with step cron - inverse",
 			schedule: "*/5,1-2 */5,1-2 */5,1-2 */5,1-2 */5,1-2",
 			want: &Cron{
-				minute:  newSet[uint8](60, 0, 1, 2, 5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55),
-				hour:    newSet[uint8](60, 0, 1, 2, 5, 10, 15, 20),
-				day:     newSet[uint8](60, 1, 2, 6, 11, 16, 21, 26, 31),
-				month:   newSet[uint8](60, 1, 2, 6, 11),
-				weekday: newSet[uint8](60, 0, 1, 2, 5),
-				utc:     true,
+				minute:  newSet[uint8](0, 1, 2, 5, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55),
+				hour:    newSet[uint8](0, 1, 2, 5, 10, 15, 20),
+				day:     newSet[uint8](1, 2, 6, 11, 16, 21, 26, 31),
+				month:   newSet[uint8](1, 2, 6, 11),
+				weekday: newSet[uint8](0, 1, 2, 5),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
 			},
 			wantErr: false,
 		},
@@ -214,8 +232,208 @@ func TestParse(t *testing.T) {
 			wantErr:  true,
 		},
 		{
-			name:     "error - range min > max",
-			schedule: "12-6 * * * *",
+			name:     "steps from a single value",
+			schedule: "5/15 5/6 10/10 2/3 1/2",
+			want: &Cron{
+				minute:  newSet[uint8](5, 20, 35, 50),
+				hour:    newSet[uint8](5, 11, 17, 23),
+				day:     newSet[uint8](10, 20, 30),
+				month:   newSet[uint8](2, 5, 8, 11),
+				weekday: newSet[uint8](1, 3, 5),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "steps from the start of a range",
+			schedule: "5-59/15 20-23/3 1-10/3 2-12/5 1-5/2",
+			want: &Cron{
+				minute:  newSet[uint8](5, 20, 35, 50),
+				hour:    newSet[uint8](20, 23),
+				day:     newSet[uint8](1, 4, 7, 10),
+				month:   newSet[uint8](2, 7, 12),
+				weekday: newSet[uint8](1, 3, 5),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "sunday as 7",
+			schedule: "0 0 * * 7",
+			want: &Cron{
+				minute:  newSet[uint8](0),
+				hour:    newSet[uint8](0),
+				day:     newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31),
+				month:   newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
+				weekday: newSet[uint8](0),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
+
+				dayStar: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "ranges ending and starting on sunday as 7",
+			schedule: "0 0 * * 5-7,7-1",
+			want: &Cron{
+				minute:  newSet[uint8](0),
+				hour:    newSet[uint8](0),
+				day:     newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31),
+				month:   newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
+				weekday: newSet[uint8](5, 6, 0, 1),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
+
+				dayStar: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "whole week with sunday as 7",
+			schedule: "0 0 * * 0-7",
+			want: &Cron{
+				minute:  newSet[uint8](0),
+				hour:    newSet[uint8](0),
+				day:     newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31),
+				month:   newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
+				weekday: newSet[uint8](0, 1, 2, 3, 4, 5, 6),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
+
+				dayStar: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "ranges wrapping around the end of the field",
+			schedule: "55-2 22-2/2 28-3 NOV-FEB FRI-MON",
+			want: &Cron{
+				minute:  newSet[uint8](55, 56, 57, 58, 59, 0, 1, 2),
+				hour:    newSet[uint8](22, 0, 2),
+				day:     newSet[uint8](28, 29, 30, 31, 1, 2, 3),
+				month:   newSet[uint8](11, 12, 1, 2),
+				weekday: newSet[uint8](5, 6, 0, 1),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "wrapping range stepping from its start",
+			schedule: "50-10/7 23-3/2 * * *",
+			want: &Cron{
+				minute:  newSet[uint8](50, 57, 4),
+				hour:    newSet[uint8](23, 1, 3),
+				day:     newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31),
+				month:   newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
+				weekday: newSet[uint8](0, 1, 2, 3, 4, 5, 6),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
+
+				dayStar:     true,
+				weekdayStar: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "wrapping and plain ranges stepping alike",
+			schedule: "0 22-2/3,20-23/3 * * *",
+			want: &Cron{
+				minute:  newSet[uint8](0),
+				hour:    newSet[uint8](22, 1, 20, 23),
+				day:     newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31),
+				month:   newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
+				weekday: newSet[uint8](0, 1, 2, 3, 4, 5, 6),
+				second:  newSet[uint8](0),
+				loc:     time.UTC,
+
+				dayStar:     true,
+				weekdayStar: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "names, ? and day rules",
+			schedule: "0 12 L-2,15W jan-Mar ?",
+			want: &Cron{
+				minute:          newSet[uint8](0),
+				hour:            newSet[uint8](12),
+				day:             newSet[uint8](),
+				month:           newSet[uint8](1, 2, 3),
+				weekday:         newSet[uint8](0, 1, 2, 3, 4, 5, 6),
+				second:          newSet[uint8](0),
+				loc:             time.UTC,
+				lastDays:        newSet[uint8](2),
+				nearestWeekdays: newSet[uint8](15),
+				weekdayStar:     true,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "weekday rules",
+			schedule: "0 12 ? * MON,FRIL,2#3,L",
+			want: &Cron{
+				minute:       newSet[uint8](0),
+				hour:         newSet[uint8](12),
+				day:          newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31),
+				month:        newSet[uint8](1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12),
+				weekday:      newSet[uint8](1, 6),
+				second:       newSet[uint8](0),
+				loc:          time.UTC,
+				lastWeekdays: newSet[uint8](5),
+				nthWeekdays:  newSet[uint8](16),
+				dayStar:      true,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "error - unknown name",
+			schedule: "* * * JUN-FOO *",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "error - ? outside the day fields",
+			schedule: "? * * * *",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "error - sixth weekday of the month",
+			schedule: "* * * * 1#6",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "error - zero step",
+			schedule: "*/0 * * * *",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "error - zero step in range",
+			schedule: "* 1-4/0 * * *",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "error - repeated step",
+			schedule: "* * * 1/2/3 *",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "error - empty list item",
+			schedule: "1,,2 * * * *",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "error - double space",
+			schedule: "*  * * *",
 			want:     nil,
 			wantErr:  true,
 		},
@@ -232,3 +450,271 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+// parseConcurrent mirrors the previous implementation of Parse which split the
+// schedule and parsed each part in its own goroutine, it is kept to compare
+// against in BenchmarkParse
+func parseConcurrent(schedule string) (*Cron, error) {
+	if schedule == "" {
+		return nil, EmptyCronSchedule
+	}
+
+	cronParts := strings.Split(schedule, " ")
+	if len(cronParts) != 5 {
+		return nil, InvalidCronSchedule
+	}
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, 5)
+	cron := &Cron{
+		second:      newSet[uint8](0),
+		loc:         time.UTC,
+		dayStar:     cronParts[2] == "*",
+		weekdayStar: cronParts[4] == "*",
+	}
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			switch i {
+			case 0:
+				cron.minute, err = parseCronPartSplit(cronParts[i], 0, 59, minute)
+			case 1:
+				cron.hour, err = parseCronPartSplit(cronParts[i], 0, 23, hour)
+			case 2:
+				cron.day, err = parseCronPartSplit(cronParts[i], 1, 31, day)
+			case 3:
+				cron.month, err = parseCronPartSplit(cronParts[i], 1, 12, month)
+			case 4:
+				cron.weekday, err = parseCronPartSplit(cronParts[i], 0, 6, weekday)
+			}
+			if err != nil {
+				errCh <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	close(errCh)
+
+	for err := range errCh {
+		if err != nil {
+			return nil, errors.Join(InvalidCronSchedule, err)
+		}
+	}
+	return cron, nil
+}
+
+func parseCronPartSplit(cronPart string, min, max uint8, part partType) (set[uint8], error) {
+	var err error
+	var offset uint8 = 0
+	if part == day || part == month {
+		offset = 1
+	}
+
+	timeSet := newSet[uint8]()
+	if cronPart == "" {
+		return timeSet, InvalidCronSchedule
+	}
+
+	for _, item := range strings.Split(cronPart, ",") {
+		steps := strings.Split(item, "/")
+		step := uint8(1)
+		if len(steps) == 2 {
+			step, err = aToi8(steps[1], min, max)
+			if err != nil {
+				return set[uint8]{}, err
+			}
+		}
+		if steps[0] == "*" {
+			timeSet.addRange(min, max, step, offset)
+			continue
+		}
+
+		ranges := strings.Split(steps[0], "-")
+		if len(ranges) == 2 {
+			localMin, err := aToi8(ranges[0], min, max)
+			if err != nil {
+				return set[uint8]{}, err
+			}
+			localMax, err := aToi8(ranges[1], min, max)
+			if err != nil {
+				return set[uint8]{}, err
+			}
+			if localMin > localMax {
+				return set[uint8]{}, errors.New("range min cannot be greater than range max")
+			}
+			timeSet.addRange(localMin, localMax, step, offset)
+			continue
+		}
+
+		toi8, err := aToi8(ranges[0], min, max)
+		if err != nil {
+			return set[uint8]{}, err
+		}
+		timeSet.add(toi8)
+	}
+
+	return timeSet, nil
+}
+
+func BenchmarkParse(b *testing.B) {
+	schedules := []string{
+		"* * * * *",
+		"1 1 1 1 1",
+		"1,2 1,2 1,2 1,2 1,2",
+		"*/5 */5 */5 */5 */5",
+		"1-5 1-5 1-5 1-5 1-5",
+		"1-2,*/5 1-2,*/5 1-2,*/5 1-2,*/5 1-2,*/5",
+	}
+	parsers := []struct {
+		name  string
+		parse func(string) (*Cron, error)
+	}{
+		{name: "sequential", parse: func(schedule string) (*Cron, error) { return Parse(schedule) }},
+		{name: "concurrent", parse: parseConcurrent},
+	}
+	for _, p := range parsers {
+		b.Run(p.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var c *Cron
+			for n := 0; n < b.N; n++ {
+				c, _ = p.parse(schedules[n%len(schedules)])
+			}
+			result = c
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, schedule := range []string{
+		"* * * * *",
+		"1 1 1 1 1",
+		"1,12 1,12 1,12 1,12 1,2",
+		"*/5 */5 */5 */5 */5",
+		"1-4/2 1-4/2 1-4/2 1-4/2 1-4/2",
+		"1-2,*/5 1-2,*/5 1-2,*/5 1-2,*/5 1-2,*/5",
+		"1-4/40 */59 31 2 6",
+		"*/0 * * * *",
+		"12-6 * * * *",
+		"quick brown fox",
+		"0 12 L,15W * MON-FRI",
+		"0 0 L-3,LW JAN,dec 5L,1#2",
+		"0 0 ? * L",
+	} {
+		f.Add(schedule)
+	}
+	f.Fuzz(func(t *testing.T, schedule string) {
+		cron, err := Parse(schedule)
+		if err != nil {
+			return
+		}
+		formatted := cron.String()
+		reparsed, err := Parse(formatted)
+		if !assert.NoError(t, err, "%q formatted as %q", schedule, formatted) {
+			return
+		}
+		assert.Equal(t, *cron, *reparsed, "%q formatted as %q", schedule, formatted)
+		assert.Equal(t, formatted, reparsed.String())
+	})
+}
+
+func TestParse_ParseError(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		opts     []Option
+		want     *ParseError
+	}{
+		{
+			name:     "wrong number of fields",
+			schedule: "* * * *",
+			want:     &ParseError{Schedule: "* * * *"},
+		},
+		{
+			name:     "minute out of range",
+			schedule: "60 * * * *",
+			want:     &ParseError{Schedule: "60 * * * *", Field: "minute", Offset: 0},
+		},
+		{
+			name:     "weekday out of range",
+			schedule: "* * * * 8",
+			want:     &ParseError{Schedule: "* * * * 8", Field: "weekday", Offset: 8},
+		},
+		{
+			name:     "day not numeric",
+			schedule: "*/5 1,2 cat * *",
+			want:     &ParseError{Schedule: "*/5 1,2 cat * *", Field: "day", Offset: 8},
+		},
+		{
+			name:     "second out of range",
+			schedule: "99 * * * * *",
+			opts:     []Option{WithSeconds()},
+			want:     &ParseError{Schedule: "99 * * * * *", Field: "second", Offset: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.schedule, tt.opts...)
+			assert.ErrorIs(t, err, InvalidCronSchedule)
+
+			var parseErr *ParseError
+			if assert.ErrorAs(t, err, &parseErr) {
+				assert.Equal(t, tt.want.Schedule, parseErr.Schedule)
+				assert.Equal(t, tt.want.Field, parseErr.Field)
+				assert.Equal(t, tt.want.Offset, parseErr.Offset)
+				assert.Error(t, parseErr.Err)
+			}
+		})
+	}
+}
+
+func TestParse_Macro(t *testing.T) {
+	tests := []struct {
+		macro string
+		opts  []Option
+		want  string
+	}{
+		{macro: "@yearly", want: "0 0 1 1 *"},
+		{macro: "@annually", want: "0 0 1 1 *"},
+		{macro: "@monthly", want: "0 0 1 * *"},
+		{macro: "@weekly", want: "0 0 * * 0"},
+		{macro: "@daily", want: "0 0 * * *"},
+		{macro: "@midnight", want: "0 0 * * *"},
+		{macro: "@hourly", want: "0 * * * *"},
+		{macro: "@hourly", opts: []Option{WithSeconds()}, want: "0 0 * * * *"},
+		{macro: "@daily", opts: []Option{WithYears()}, want: "0 0 * * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.macro, func(t *testing.T) {
+			cron, err := Parse(tt.macro, tt.opts...)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, cron.String())
+			}
+		})
+	}
+
+	for _, macro := range []string{"@reboot", "@Daily", "@daily ", "@"} {
+		_, err := Parse(macro)
+		assert.ErrorIs(t, err, InvalidCronSchedule, macro)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	assert.NotPanics(t, func() {
+		cron := MustParse("*/5 * * * *")
+		assert.Equal(t, "*/5 * * * *", cron.String())
+	})
+
+	defer func() {
+		err, ok := recover().(error)
+		assert.True(t, ok)
+		var parseErr *ParseError
+		assert.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "hour", parseErr.Field)
+	}()
+	MustParse("* 24 * * *")
+	t.Fatal("MustParse did not panic")
+}
@@ -0,0 +1,77 @@
+package cron
+
+import (
+	"errors"
+	"strings"
+)
+
+var quartz = dialect{
+	weekdayBase: 1,
+	lastYear:    2099,
+}
+
+/*
+ParseQuartz takes a Quartz cron expression (0 0/5 14,18 ? * MON-FRI *) and
+returns a Cron object if the expression is valid; otherwise, it returns an error.
+Options may be given to change how the schedule is calculated.
+
+Quartz expressions differ from standard schedules: they always begin with a
+seconds field and may end with a years field, they number the days of the week
+from 1 (SUN) to 7 (SAT), a step counts from the value before it (i.e. 0/5), and
+? must be used in exactly one of the day of month and day of week fields:
+
+* [0-59]      (* , / -)
+
+* [0-59]      (* , / -)
+
+* [0-23]      (* , / -)
+
+* [1-31]      (* , / - ? L W)
+
+* [1-12]      (* , / -)    JAN-DEC
+
+* [1-7]       (* , / - ? L #)    SUN-SAT
+
+* [1970-2099] (* , / -)    optional
+*/
+func ParseQuartz(expression string, opts ...Option) (*Cron, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, EmptyCronSchedule
+	}
+
+	conf := newConfig(opts)
+	cron := conf.Cron
+
+	var cronParts [maxFields]string
+	var offsets [maxFields]int
+	parts := splitFields(strings.ToUpper(expression), &cronParts, &offsets)
+	if parts != 6 && parts != 7 {
+		return nil, &ParseError{
+			Schedule: expression,
+			Err:      errors.New("expected 6 or 7 fields"),
+		}
+	}
+	cron.seconds = true
+	cron.years = parts == 7
+
+	if err := checkQuestionMark(expression, cronParts[3], cronParts[5]); err != nil {
+		return nil, err
+	}
+
+	if err := quartz.parseFields(conf, expression, cronParts[:parts], offsets[:parts]); err != nil {
+		return nil, err
+	}
+	return cron, nil
+}
+
+// checkQuestionMark requires ? in exactly one of the day of month and day of week
+// fields, as Quartz and EventBridge do
+func checkQuestionMark(expression, day, weekday string) error {
+	if (day == "?") == (weekday == "?") {
+		return &ParseError{
+			Schedule: expression,
+			Err:      errors.New("? must be used in exactly one of the day of month and day of week fields"),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestParseQuartz follows the examples of the Quartz CronTrigger tutorial
+func TestParseQuartz(t *testing.T) {
+	from := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		expression string
+		from       time.Time
+		want       []time.Time
+	}{
+		{
+			name:       "12pm every day",
+			expression: "0 0 12 * * ?",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 12, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 19, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am every day",
+			expression: "0 15 10 ? * *",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 10, 15, 0, 0, time.UTC),
+				time.Date(2023, 6, 19, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am every day, every year",
+			expression: "0 15 10 * * ? *",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 10, 15, 0, 0, time.UTC),
+				time.Date(2023, 6, 19, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am every day during 2005",
+			expression: "0 15 10 * * ? 2005",
+			from:       time.Date(2004, 12, 31, 11, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2005, 1, 1, 10, 15, 0, 0, time.UTC),
+				time.Date(2005, 1, 2, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am every day during 2005, after 2005",
+			expression: "0 15 10 * * ? 2005",
+			want:       []time.Time{{}},
+		},
+		{
+			name:       "every minute from 2pm to 2:59pm",
+			expression: "0 * 14 * * ?",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 14, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 18, 14, 1, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every 5 minutes from 2pm to 2:55pm",
+			expression: "0 0/5 14 * * ?",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 14, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 18, 14, 5, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every 5 minutes from 2pm to 2:55pm and 6pm to 6:55pm",
+			expression: "0 0/5 14,18 * * ?",
+			want: []time.Time{
+				time.Date(2023, 6, 17, 18, 25, 0, 0, time.UTC),
+				time.Date(2023, 6, 17, 18, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every minute from 2pm to 2:05pm",
+			expression: "0 0-5 14 * * ?",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 14, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 18, 14, 1, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "2:10pm and 2:44pm every Wednesday in March",
+			expression: "0 10,44 14 ? 3 WED",
+			want: []time.Time{
+				time.Date(2024, 3, 6, 14, 10, 0, 0, time.UTC),
+				time.Date(2024, 3, 6, 14, 44, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am every weekday",
+			expression: "0 15 10 ? * MON-FRI",
+			want: []time.Time{
+				time.Date(2023, 6, 19, 10, 15, 0, 0, time.UTC),
+				time.Date(2023, 6, 20, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am on the 15th day of every month",
+			expression: "0 15 10 15 * ?",
+			want: []time.Time{
+				time.Date(2023, 7, 15, 10, 15, 0, 0, time.UTC),
+				time.Date(2023, 8, 15, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am on the last day of every month",
+			expression: "0 15 10 L * ?",
+			want: []time.Time{
+				time.Date(2023, 6, 30, 10, 15, 0, 0, time.UTC),
+				time.Date(2023, 7, 31, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am on the second to last day of every month",
+			expression: "0 15 10 L-2 * ?",
+			want: []time.Time{
+				time.Date(2023, 6, 28, 10, 15, 0, 0, time.UTC),
+				time.Date(2023, 7, 29, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am on the last Friday of every month",
+			expression: "0 15 10 ? * 6L",
+			want: []time.Time{
+				time.Date(2023, 6, 30, 10, 15, 0, 0, time.UTC),
+				time.Date(2023, 7, 28, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am on the last Friday of every month during 2002 to 2005",
+			expression: "0 15 10 ? * 6L 2002-2005",
+			from:       time.Date(2001, 6, 17, 18, 23, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2002, 1, 25, 10, 15, 0, 0, time.UTC),
+				time.Date(2002, 2, 22, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "10:15am on the third Friday of every month",
+			expression: "0 15 10 ? * 6#3",
+			want: []time.Time{
+				time.Date(2023, 7, 21, 10, 15, 0, 0, time.UTC),
+				time.Date(2023, 8, 18, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "12pm every 5 days starting on the first day of the month",
+			expression: "0 0 12 1/5 * ?",
+			want: []time.Time{
+				time.Date(2023, 6, 21, 12, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 26, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "11:11am every November 11th",
+			expression: "0 11 11 11 11 ?",
+			want: []time.Time{
+				time.Date(2023, 11, 11, 11, 11, 0, 0, time.UTC),
+				time.Date(2024, 11, 11, 11, 11, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every 5 minutes at 2pm and 6pm on weekdays",
+			expression: "0 0/5 14,18 ? * MON-FRI *",
+			want: []time.Time{
+				time.Date(2023, 6, 19, 14, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 19, 14, 5, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "midnight on the last weekday of every month",
+			expression: "0 0 0 LW * ?",
+			want: []time.Time{
+				time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 7, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "midnight on the weekday nearest the 15th",
+			expression: "0 0 0 15W * ?",
+			want: []time.Time{
+				time.Date(2023, 7, 14, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 8, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "midnight on the weekday nearest the 1st, within the month",
+			expression: "0 0 0 1W * ?",
+			want: []time.Time{
+				time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every 30 seconds on Saturday, lowercase",
+			expression: "*/30 * * ? * sat",
+			want: []time.Time{
+				time.Date(2023, 6, 17, 18, 23, 30, 0, time.UTC),
+				time.Date(2023, 6, 17, 18, 24, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "L is Saturday in the day of week field",
+			expression: "0 0 0 ? * L",
+			want: []time.Time{
+				time.Date(2023, 6, 24, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cron, err := ParseQuartz(tt.expression)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			next := from
+			if !tt.from.IsZero() {
+				next = tt.from
+			}
+			for _, want := range tt.want {
+				next = cron.NextFrom(next)
+				assert.True(t, want.Equal(next), "want %s, got %s", want, next)
+			}
+
+			// The canonical form reads back identically with the matching options
+			opts := []Option{WithSeconds()}
+			if cron.years {
+				opts = append(opts, WithYears())
+			}
+			reparsed, err := Parse(cron.String(), opts...)
+			if assert.NoError(t, err, cron.String()) {
+				assert.Equal(t, *cron, *reparsed, cron.String())
+			}
+		})
+	}
+}
+
+func TestParseQuartz_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "empty", expression: " "},
+		{name: "standard schedule", expression: "0 12 * * *"},
+		{name: "too many fields", expression: "0 0 12 * * ? * *"},
+		{name: "no question mark", expression: "0 0 12 * * *"},
+		{name: "two question marks", expression: "0 0 12 ? * ?"},
+		{name: "weekday zero", expression: "0 0 12 ? * 0"},
+		{name: "weekday eight", expression: "0 0 12 ? * 8"},
+		{name: "sixth weekday of the month", expression: "0 0 12 ? * 6#6"},
+		{name: "year before 1970", expression: "0 0 12 ? * * 1969"},
+		{name: "year after 2099", expression: "0 0 12 ? * * 2100"},
+		{name: "too many days before the last", expression: "0 0 12 L-31 * ?"},
+		{name: "nearest weekday of no day", expression: "0 0 12 W * ?"},
+		{name: "unknown month", expression: "0 0 12 ? FOO *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQuartz(tt.expression)
+			assert.Error(t, err)
+		})
+	}
+}
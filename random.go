@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+)
+
+// parseRandom turns a random item of a cron part (0~30, ~ or 0~59/15) into the values it
+// stands for, chosen once from the random source of cron. An omitted bound is the
+// bound of the field, and a step starts from a random value within the first step
+func (d dialect) parseRandom(cron *config, base string, step uint8, hasStep bool, min, max uint8, part partType) (set[uint8], error) {
+	timeSet := newSet[uint8]()
+	first, last, _ := strings.Cut(base, "~")
+
+	lower, upper := min, max
+	var err error
+	if first != "" {
+		lower, err = parseValue(first, min, max, part)
+		if err != nil {
+			return timeSet, err
+		}
+	}
+	if last != "" {
+		upper, err = parseValue(last, min, max, part)
+		if err != nil {
+			return timeSet, err
+		}
+	}
+	if lower > upper {
+		return timeSet, errors.New("range min cannot be greater than range max")
+	}
+
+	span := upper - lower + 1
+	if hasStep && step < span {
+		span = step
+	}
+	start := lower + uint8(cron.intn(int(span)))
+	if hasStep {
+		timeSet.addRange(start, upper, step, start)
+	} else {
+		timeSet.add(start)
+	}
+	return timeSet, nil
+}
+
+// intn returns a random number in [0, n) from the random source of the
+// config, or from the shared source of math/rand when there is none
+func (c *config) intn(n int) int {
+	if c.random == nil {
+		return rand.Intn(n)
+	}
+	return c.random.Intn(n)
+}
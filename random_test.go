@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestParse_Random(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		check    func(t *testing.T, c *Cron)
+	}{
+		{
+			name:     "range",
+			schedule: "0~30 * * * *",
+			check: func(t *testing.T, c *Cron) {
+				minutes := values(c.minute, 0, 59)
+				if assert.Len(t, minutes, 1) {
+					assert.LessOrEqual(t, minutes[0], 30)
+				}
+			},
+		},
+		{
+			name:     "whole field",
+			schedule: "0 ~ * * *",
+			check: func(t *testing.T, c *Cron) {
+				assert.Len(t, values(c.hour, 0, 23), 1)
+			},
+		},
+		{
+			name:     "omitted upper bound",
+			schedule: "0 0 20~ * *",
+			check: func(t *testing.T, c *Cron) {
+				days := values(c.day, 1, 31)
+				if assert.Len(t, days, 1) {
+					assert.GreaterOrEqual(t, days[0], 20)
+				}
+			},
+		},
+		{
+			name:     "omitted lower bound",
+			schedule: "0 0 * ~6 *",
+			check: func(t *testing.T, c *Cron) {
+				months := values(c.month, 1, 12)
+				if assert.Len(t, months, 1) {
+					assert.LessOrEqual(t, months[0], 6)
+				}
+			},
+		},
+		{
+			name:     "stepped",
+			schedule: "0~59/15 * * * *",
+			check: func(t *testing.T, c *Cron) {
+				minutes := values(c.minute, 0, 59)
+				if assert.Len(t, minutes, 4) {
+					assert.Less(t, minutes[0], 15)
+					assert.Equal(t, []int{minutes[0], minutes[0] + 15, minutes[0] + 30, minutes[0] + 45}, minutes)
+				}
+			},
+		},
+		{
+			name:     "weekday names",
+			schedule: "0 0 * * MON~FRI",
+			check: func(t *testing.T, c *Cron) {
+				weekdays := values(c.weekday, 0, 6)
+				if assert.Len(t, weekdays, 1) {
+					assert.GreaterOrEqual(t, weekdays[0], 1)
+					assert.LessOrEqual(t, weekdays[0], 5)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every seed must choose values within the bounds
+			for seed := int64(0); seed < 50; seed++ {
+				cron, err := Parse(tt.schedule, WithRand(rand.New(rand.NewSource(seed))))
+				if !assert.NoError(t, err) {
+					return
+				}
+				tt.check(t, cron)
+			}
+		})
+	}
+}
+
+func TestParse_RandomReproducible(t *testing.T) {
+	first, err := Parse("0~59 0~23 * * *", WithRand(rand.New(rand.NewSource(42))))
+	if !assert.NoError(t, err) {
+		return
+	}
+	second, err := Parse("0~59 0~23 * * *", WithRand(rand.New(rand.NewSource(42))))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, first.String(), second.String())
+
+	// Over enough seeds every value of the range is chosen
+	chosen := map[string]bool{}
+	for seed := int64(0); seed < 200; seed++ {
+		cron, err := Parse("0~3 * * * *", WithRand(rand.New(rand.NewSource(seed))))
+		if assert.NoError(t, err) {
+			chosen[cron.String()] = true
+		}
+	}
+	assert.Len(t, chosen, 4)
+
+	// Without a source the shared source of math/rand is used
+	_, err = Parse("0~30 * * * *")
+	assert.NoError(t, err)
+}
+
+func TestParse_RandomError(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+	}{
+		{name: "backwards range", schedule: "30~10 * * * *"},
+		{name: "outside the field", schedule: "0 0~24 * * *"},
+		{name: "unknown name", schedule: "0 0 * * MON~FOO"},
+		{name: "two tildes", schedule: "0~10~20 * * * *"},
+		{name: "zero step", schedule: "0~59/0 * * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.schedule)
+			assert.Error(t, err)
+		})
+	}
+}
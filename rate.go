@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+/*
+Rate is a schedule that activates at a fixed interval from its start,
+such as the rate() expressions of EventBridge. A Rate is not modified
+once it has been created, so it is safe for concurrent use
+*/
+type Rate struct {
+	interval time.Duration
+	start    time.Time
+	loc      *time.Location
+	clock    Clock
+}
+
+/*
+NewRate returns a Rate that first activates at start and then every interval, its
+activations are in the location of start. Next, Prev and Now read the time from
+the clock, or from SystemClock when it is nil
+*/
+func NewRate(interval time.Duration, start time.Time, clock Clock) (*Rate, error) {
+	if interval <= 0 {
+		return nil, errors.New("rate interval must be greater than zero")
+	}
+	return &Rate{
+		interval: interval,
+		start:    start,
+		loc:      start.Location(),
+		clock:    clock,
+	}, nil
+}
+
+/*
+Interval returns the time between two activations of the Rate
+*/
+func (r *Rate) Interval() time.Duration {
+	return r.interval
+}
+
+/*
+NextFrom accepts a time in which it will calculate the next activation time after
+*/
+func (r *Rate) NextFrom(from time.Time) time.Time {
+	if from.Before(r.start) {
+		return r.start.In(r.loc)
+	}
+	n := from.Sub(r.start)/r.interval + 1
+	return r.start.Add(n * r.interval).In(r.loc)
+}
+
+/*
+PrevBefore accepts a time in which it will calculate the previous activation time before.
+If before is not after the start of the Rate, the zero time is returned
+*/
+func (r *Rate) PrevBefore(before time.Time) time.Time {
+	if !before.After(r.start) {
+		return time.Time{}
+	}
+	n := (before.Sub(r.start) - 1) / r.interval
+	return r.start.Add(n * r.interval).In(r.loc)
+}
+
+/*
+Next will return the next activation after now
+*/
+func (r *Rate) Next() time.Time {
+	return r.NextFrom(r.now())
+}
+
+/*
+Prev will return the previous activation before now
+*/
+func (r *Rate) Prev() time.Time {
+	return r.PrevBefore(r.now())
+}
+
+/*
+Now will tell you the Rate activates within the current minute
+*/
+func (r *Rate) Now() bool {
+	minute := r.now().Truncate(time.Minute)
+	return r.NextFrom(minute.Add(-1)).Before(minute.Add(time.Minute))
+}
+
+func (r *Rate) now() time.Time {
+	clock := r.clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	return clock.Now().In(r.loc)
+}
@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRate(t *testing.T) {
+	start := time.Date(2023, 6, 17, 18, 23, 30, 0, time.UTC)
+	clock := NewFakeClock(time.Date(2023, 6, 17, 18, 40, 0, 0, time.UTC))
+	rate, err := NewRate(15*time.Minute, start, clock)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 15*time.Minute, rate.Interval())
+	assert.Equal(t, start, rate.NextFrom(start.Add(-1*time.Hour)))
+	assert.Equal(t, start.Add(15*time.Minute), rate.NextFrom(start))
+	assert.Equal(t, time.Time{}, rate.PrevBefore(start))
+	assert.Equal(t, start, rate.PrevBefore(start.Add(time.Second)))
+	assert.Equal(t, start.Add(15*time.Minute), rate.PrevBefore(start.Add(30*time.Minute)))
+
+	assert.Equal(t, time.Date(2023, 6, 17, 18, 53, 30, 0, time.UTC), rate.Next())
+	assert.Equal(t, time.Date(2023, 6, 17, 18, 38, 30, 0, time.UTC), rate.Prev())
+	assert.False(t, rate.Now())
+	clock.Set(time.Date(2023, 6, 17, 18, 53, 0, 0, time.UTC))
+	assert.True(t, rate.Now())
+
+	_, err = NewRate(0, start, nil)
+	assert.Error(t, err)
+}
+
+func TestRate_Location(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(t, err) {
+		return
+	}
+	rate, err := NewRate(time.Hour, time.Unix(0, 0).In(loc), nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	next := rate.NextFrom(time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC))
+	assert.Equal(t, loc, next.Location())
+	assert.True(t, time.Date(2023, 6, 17, 19, 0, 0, 0, time.UTC).Equal(next))
+}
+
+func TestRate_EventBridge(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		want     string
+		wantErr  bool
+	}{
+		{interval: time.Minute, want: "rate(1 minute)"},
+		{interval: 90 * time.Minute, want: "rate(90 minutes)"},
+		{interval: 2 * time.Hour, want: "rate(2 hours)"},
+		{interval: 24 * time.Hour, want: "rate(1 day)"},
+		{interval: 30 * time.Second, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.interval.String(), func(t *testing.T) {
+			rate, err := NewRate(tt.interval, time.Unix(0, 0), nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			got, err := rate.EventBridge()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, UnrepresentableSchedule), "want UnrepresentableSchedule, got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,447 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rruleFreqs are the frequencies of an RRULE, from the finest to the coarsest
+var rruleFreqs = []string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+const (
+	secondly = iota
+	minutely
+	hourly
+	daily
+	weekly
+	monthly
+	yearly
+)
+
+// rruleUntil is the layout of an UNTIL in UTC
+const rruleUntil = "20060102T150405Z"
+
+// rruleWeekdays are the days of the week of an RRULE, from Sunday
+var rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+/*
+RRule returns the schedule as iCalendar (RFC 5545) recurrence rules, without the
+RRULE: prefix. The activations of the schedule are those of all the rules together,
+a schedule whose days may match either the day of the month or the day of the week
+takes a rule for each, as do days of the week counted within the month (i.e. 5#3)
+alongside plain ones. The rules are in the location of the Cron, which is the time
+zone of their DTSTART, and the DTSTART should fall on an activation, or at least on
+a whole minute. A NotAfter bound ends each rule with an UNTIL, a NotBefore bound is
+left to the DTSTART. An error matching UnrepresentableSchedule is returned for the W
+rules, for restricted years, for the days excluded by a calendar and for business days
+*/
+func (c *Cron) RRule() ([]string, error) {
+	if c.lastWeekday || c.nearestWeekdays.bits != 0 {
+		return nil, fmt.Errorf("%w as an RRULE: uses the W rules", UnrepresentableSchedule)
+	}
+	if c.year != (yearSet{}) {
+		return nil, fmt.Errorf("%w as an RRULE: restricts the years", UnrepresentableSchedule)
+	}
+	if len(c.calendars) > 0 {
+		return nil, fmt.Errorf("%w as an RRULE: excludes the days of a calendar", UnrepresentableSchedule)
+	}
+	if c.businessDays {
+		return nil, fmt.Errorf("%w as an RRULE: keeps to business days", UnrepresentableSchedule)
+	}
+
+	var rules []string
+	monthdays := c.rruleMonthdays()
+	weekdays, counted := c.rruleWeekdays()
+	switch {
+	case c.dayMatch == DayMatchOr && !c.dayStar && !c.weekdayStar && (monthdays == "" || (weekdays == "" && counted == "")):
+		// Either field matching every day makes every day match
+		rules = []string{c.rrule("", "", false)}
+	case c.dayMatch == DayMatchOr && !c.dayStar && !c.weekdayStar:
+		rules = c.rrules("", weekdays, counted, c.rrule(monthdays, "", false))
+	default:
+		rules = c.rrules(monthdays, weekdays, counted)
+	}
+	if !c.notAfter.IsZero() {
+		for i := range rules {
+			rules[i] += ";UNTIL=" + c.notAfter.UTC().Format(rruleUntil)
+		}
+	}
+	return rules, nil
+}
+
+// rrules writes a rule for the days of the week and another for the days counted
+// within the month, as the two do not mix in a single BYDAY where all readers agree
+func (c *Cron) rrules(monthdays, weekdays, counted string, rules ...string) []string {
+	if weekdays != "" || counted == "" {
+		rules = append(rules, c.rrule(monthdays, weekdays, false))
+	}
+	if counted != "" {
+		rules = append(rules, c.rrule(monthdays, counted, true))
+	}
+	return rules
+}
+
+// rrule writes a rule for the time fields of the schedule on the given days, the
+// frequency is the finest field that is not restricted so that no part of the
+// rule is taken from DTSTART, and MONTHLY when the days of the week are counted
+func (c *Cron) rrule(monthdays, weekdays string, ordinal bool) string {
+	freq := daily
+	switch {
+	case ordinal:
+		freq = monthly
+	case c.second == fullSet(0, 59):
+		freq = secondly
+	case c.minute == fullSet(0, 59):
+		freq = minutely
+	case c.hour == fullSet(0, 23):
+		freq = hourly
+	}
+
+	parts := []string{"FREQ=" + rruleFreqs[freq]}
+	if c.month != fullSet(1, 12) {
+		parts = append(parts, "BYMONTH="+rruleList(c.month, 1, 12))
+	}
+	if monthdays != "" {
+		parts = append(parts, "BYMONTHDAY="+monthdays)
+	}
+	if weekdays != "" {
+		parts = append(parts, "BYDAY="+weekdays)
+	}
+	if freq > hourly || c.hour != fullSet(0, 23) {
+		parts = append(parts, "BYHOUR="+rruleList(c.hour, 0, 23))
+	}
+	if freq > minutely || c.minute != fullSet(0, 59) {
+		parts = append(parts, "BYMINUTE="+rruleList(c.minute, 0, 59))
+	}
+	// A second of 0 is left to DTSTART, which is on a whole minute
+	if freq > secondly && c.second != newSet[uint8](0) {
+		parts = append(parts, "BYSECOND="+rruleList(c.second, 0, 59))
+	}
+	return strings.Join(parts, ";")
+}
+
+// rruleMonthdays writes the days of the month of the schedule for BYMONTHDAY,
+// counting the L rules from the end, or returns "" for every day
+func (c *Cron) rruleMonthdays() string {
+	if c.day == fullSet(1, 31) {
+		return ""
+	}
+	days := rruleList(c.day, 1, 31)
+	for before := 0; before <= 30; before++ {
+		if c.lastDays.contains(uint8(before)) {
+			days = joinCronPart(days, strconv.Itoa(-1-before))
+		}
+	}
+	return days
+}
+
+// rruleWeekdays writes the days of the week of the schedule for BYDAY, or returns
+// "" for every day, and separately those counted within the month (i.e. 2TU)
+func (c *Cron) rruleWeekdays() (string, string) {
+	if c.weekday == fullSet(0, 6) {
+		return "", ""
+	}
+	var weekdays, counted []string
+	for weekday := 0; weekday <= 6; weekday++ {
+		if c.weekday.contains(uint8(weekday)) {
+			weekdays = append(weekdays, rruleWeekdays[weekday])
+		}
+	}
+	for i := 0; i < 35; i++ {
+		if c.nthWeekdays.contains(uint8(i)) {
+			counted = append(counted, strconv.Itoa(i/7+1)+rruleWeekdays[i%7])
+		}
+	}
+	for weekday := 0; weekday <= 6; weekday++ {
+		if c.lastWeekdays.contains(uint8(weekday)) {
+			counted = append(counted, "-1"+rruleWeekdays[weekday])
+		}
+	}
+	return strings.Join(weekdays, ","), strings.Join(counted, ",")
+}
+
+// fullSet returns the set of every value between min and max
+func fullSet(min, max uint8) set[uint8] {
+	full := newSet[uint8]()
+	full.addRange(min, max, 1, min)
+	return full
+}
+
+// rruleList writes the values of a set as a comma separated list
+func rruleList(timeSet set[uint8], min, max uint8) string {
+	var values []string
+	for v := int(min); v <= int(max); v++ {
+		if timeSet.contains(uint8(v)) {
+			values = append(values, strconv.Itoa(v))
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+/*
+ParseRRule takes an iCalendar (RFC 5545) recurrence rule, with or without the
+RRULE: prefix, and the DTSTART it recurs from, and returns a Cron with the same
+activations if the rule is one a Cron can represent; otherwise, it returns an
+error. The Cron is calculated in the location of dtstart, and like any Cron it
+also activates before dtstart. Options may be given to change how the schedule
+is calculated.
+
+The rule parts FREQ, INTERVAL, UNTIL, BYMONTH, BYMONTHDAY, BYDAY, BYHOUR, BYMINUTE,
+BYSECOND and WKST are read, parts left out are taken from dtstart as RFC 5545
+describes. An INTERVAL is only accepted where it divides the next larger unit
+evenly, i.e. FREQ=MINUTELY;INTERVAL=15, or for months FREQ=MONTHLY;INTERVAL=3.
+BYDAY may count the days of the week within the month (i.e. 2TU or -1FR) with
+FREQ=MONTHLY, or with FREQ=YEARLY and BYMONTH. UNTIL sets the NotAfter bound of
+the Cron, as a time in UTC, a time in the location of dtstart or the end of a date.
+COUNT, BYSETPOS, BYWEEKNO and BYYEARDAY are not supported.
+*/
+func ParseRRule(rule string, dtstart time.Time, opts ...Option) (*Cron, error) {
+	if strings.TrimSpace(rule) == "" {
+		return nil, EmptyCronSchedule
+	}
+	parts, err := parseRRuleParts(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	cron := newCron(opts)
+	cron.loc = dtstart.Location()
+	cron.dayMatch = DayMatchAnd
+	cron.years = false
+	dtstart = dtstart.In(cron.loc)
+
+	freqPart, ok := parts["FREQ"]
+	if !ok {
+		return nil, &ParseError{Schedule: rule, Err: errors.New("FREQ is required")}
+	}
+	freq := -1
+	for i, name := range rruleFreqs {
+		if freqPart.value == name {
+			freq = i
+		}
+	}
+	if freq < 0 {
+		return nil, freqPart.errorf(rule, "unknown frequency %q", freqPart.value)
+	}
+
+	interval := 1
+	if part, ok := parts["INTERVAL"]; ok {
+		interval, err = strconv.Atoi(part.value)
+		if err != nil || interval < 1 {
+			return nil, part.errorf(rule, "%q is not a positive whole number", part.value)
+		}
+	}
+
+	if interval > 1 && freq != secondly && freq != minutely && freq != hourly && freq != monthly {
+		return nil, parts["INTERVAL"].errorf(rule, "an interval of %d is not supported with FREQ=%s", interval, rruleFreqs[freq])
+	}
+	_, hasMonthdays := parts["BYMONTHDAY"]
+	_, hasWeekdays := parts["BYDAY"]
+
+	// The fields finer than the frequency take the value of dtstart, the field
+	// of the frequency steps by the interval from dtstart and the fields coarser
+	// than it take every value, unless the rule lists them
+	timeFields := []struct {
+		name     string
+		freq     int
+		min, max uint8
+		value    int
+		within   string
+		timeSet  *set[uint8]
+	}{
+		{"BYSECOND", secondly, 0, 59, dtstart.Second(), "a minute", &cron.second},
+		{"BYMINUTE", minutely, 0, 59, dtstart.Minute(), "an hour", &cron.minute},
+		{"BYHOUR", hourly, 0, 23, dtstart.Hour(), "a day", &cron.hour},
+		{"BYMONTH", monthly, 1, 12, int(dtstart.Month()), "a year", &cron.month},
+	}
+	for _, field := range timeFields {
+		switch {
+		case field.freq == freq:
+			if int(field.max-field.min+1)%interval != 0 {
+				return nil, parts["INTERVAL"].errorf(rule, "an interval of %d does not divide %s evenly", interval, field.within)
+			}
+			first := field.min + uint8((field.value-int(field.min))%interval)
+			field.timeSet.addRange(first, field.max, uint8(interval), first)
+		case field.freq < freq && !(field.freq == monthly && (hasMonthdays || hasWeekdays)):
+			// The months of a yearly rule only come from dtstart when it lists no days
+			field.timeSet.add(uint8(field.value))
+		default:
+			*field.timeSet = fullSet(field.min, field.max)
+		}
+
+		part, ok := parts[field.name]
+		if !ok {
+			continue
+		}
+		listed, err := parseRRuleList(part.value, int(field.min), int(field.max))
+		if err != nil {
+			return nil, part.errorf(rule, "%v", err)
+		}
+		if field.freq == freq {
+			// The list narrows the values stepped by the interval
+			listed.bits &= field.timeSet.bits
+		}
+		*field.timeSet = listed
+	}
+	cron.seconds = cron.second != newSet[uint8](0)
+
+	if err := parseRRuleDays(cron, rule, parts, freq, dtstart); err != nil {
+		return nil, err
+	}
+	if part, ok := parts["UNTIL"]; ok {
+		if cron.notAfter, err = parseRRuleUntil(part.value, cron.loc); err != nil {
+			return nil, part.errorf(rule, "%q is not a date or a time", part.value)
+		}
+	}
+	return cron, nil
+}
+
+// parseRRuleUntil reads an UNTIL as the last time the rule may activate. A date
+// lasts until its end, a time without the Z of UTC is in the location of dtstart
+func parseRRuleUntil(value string, loc *time.Location) (time.Time, error) {
+	if until, err := time.Parse(rruleUntil, value); err == nil {
+		return until, nil
+	}
+	if until, err := time.ParseInLocation(strings.TrimSuffix(rruleUntil, "Z"), value, loc); err == nil {
+		return until, nil
+	}
+	date, err := time.ParseInLocation("20060102", value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return startOfDay(date.Year(), date.Month(), date.Day()+1, loc).Add(-time.Nanosecond), nil
+}
+
+// rrulePart is the value of a rule part and where it is within the rule
+type rrulePart struct {
+	value  string
+	offset int
+	name   string
+}
+
+func (p rrulePart) errorf(rule, format string, args ...any) error {
+	return &ParseError{Schedule: rule, Field: p.name, Offset: p.offset, Err: fmt.Errorf(format, args...)}
+}
+
+// parseRRuleParts splits a rule into its NAME=value parts, by name
+func parseRRuleParts(rule string) (map[string]rrulePart, error) {
+	body := strings.TrimSpace(rule)
+	offset := strings.Index(rule, body)
+	if len(body) >= len("RRULE:") && strings.EqualFold(body[:len("RRULE:")], "RRULE:") {
+		body = body[len("RRULE:"):]
+		offset += len("RRULE:")
+	}
+
+	parts := map[string]rrulePart{}
+	for more := true; more; {
+		var item string
+		item, body, more = strings.Cut(body, ";")
+		name, value, ok := strings.Cut(item, "=")
+		name = strings.ToUpper(name)
+		part := rrulePart{value: strings.ToUpper(value), offset: offset, name: name}
+		offset += len(item) + 1
+
+		switch {
+		case !ok || value == "":
+			return nil, &ParseError{Schedule: rule, Offset: part.offset, Err: fmt.Errorf("expected NAME=value, got %q", item)}
+		case name == "COUNT" || name == "BYSETPOS" || name == "BYWEEKNO" || name == "BYYEARDAY":
+			return nil, part.errorf(rule, "%s is not supported", name)
+		}
+		switch name {
+		case "FREQ", "INTERVAL", "UNTIL", "BYMONTH", "BYMONTHDAY", "BYDAY", "BYHOUR", "BYMINUTE", "BYSECOND", "WKST":
+		default:
+			return nil, part.errorf(rule, "unknown rule part %s", name)
+		}
+		if _, ok := parts[name]; ok {
+			return nil, part.errorf(rule, "%s is given more than once", name)
+		}
+		parts[name] = part
+	}
+	return parts, nil
+}
+
+// parseRRuleList parses a comma separated list of values between min and max
+func parseRRuleList(list string, min, max int) (set[uint8], error) {
+	timeSet := set[uint8]{}
+	for _, item := range strings.Split(list, ",") {
+		value, err := strconv.Atoi(item)
+		if err != nil || value < min || value > max {
+			return timeSet, fmt.Errorf("%q is outside the range %d-%d", item, min, max)
+		}
+		timeSet.add(uint8(value))
+	}
+	return timeSet, nil
+}
+
+// parseRRuleDays sets the day fields of cron from BYMONTHDAY and BYDAY, or from
+// dtstart when the frequency calls for it
+func parseRRuleDays(cron *Cron, rule string, parts map[string]rrulePart, freq int, dtstart time.Time) error {
+	cron.day = fullSet(1, 31)
+	cron.weekday = fullSet(0, 6)
+	monthdays, hasMonthdays := parts["BYMONTHDAY"]
+	weekdays, hasWeekdays := parts["BYDAY"]
+
+	if hasMonthdays {
+		if freq == weekly {
+			return monthdays.errorf(rule, "BYMONTHDAY is not allowed with FREQ=WEEKLY")
+		}
+		cron.day = set[uint8]{}
+		for _, item := range strings.Split(monthdays.value, ",") {
+			value, err := strconv.Atoi(item)
+			switch {
+			case err != nil || value == 0 || value < -31 || value > 31:
+				return monthdays.errorf(rule, "%q is outside the range 1-31 or -31--1", item)
+			case value < 0:
+				cron.lastDays.add(uint8(-1 - value))
+			default:
+				cron.day.add(uint8(value))
+			}
+		}
+	}
+
+	if hasWeekdays {
+		cron.weekday = set[uint8]{}
+		for _, item := range strings.Split(weekdays.value, ",") {
+			weekday := -1
+			for i, name := range rruleWeekdays {
+				if strings.HasSuffix(item, name) {
+					weekday = i
+				}
+			}
+			if weekday < 0 {
+				return weekdays.errorf(rule, "unknown day %q", item)
+			}
+			nth := item[:len(item)-2]
+			if nth == "" {
+				cron.weekday.add(uint8(weekday))
+				continue
+			}
+			if freq != monthly && (freq != yearly || parts["BYMONTH"].value == "") {
+				return weekdays.errorf(rule, "%q is only supported with FREQ=MONTHLY, or FREQ=YEARLY and BYMONTH", item)
+			}
+			n, err := strconv.Atoi(nth)
+			switch {
+			case n == -1:
+				cron.lastWeekdays.add(uint8(weekday))
+			case err == nil && n >= 1 && n <= 5:
+				cron.nthWeekdays.add(uint8((n-1)*7 + weekday))
+			default:
+				return weekdays.errorf(rule, "%q counts other than 1-5 or -1 days within the month", item)
+			}
+		}
+	}
+
+	// Without either list, the day comes from dtstart
+	if !hasMonthdays && !hasWeekdays {
+		switch freq {
+		case weekly:
+			cron.weekday = newSet(uint8(dtstart.Weekday()))
+		case monthly, yearly:
+			cron.day = newSet(uint8(dtstart.Day()))
+		}
+	}
+	return nil
+}
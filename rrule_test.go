@@ -0,0 +1,276 @@
+package cron
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCron_RRule(t *testing.T) {
+	tests := []struct {
+		schedule string
+		opts     []Option
+		want     []string
+	}{
+		{schedule: "* * * * *", want: []string{"FREQ=MINUTELY"}},
+		{schedule: "*/15 * * * *", want: []string{"FREQ=HOURLY;BYMINUTE=0,15,30,45"}},
+		{schedule: "0 9 * * 1-5", want: []string{"FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0"}},
+		{schedule: "* 9 * * *", want: []string{"FREQ=MINUTELY;BYHOUR=9"}},
+		{schedule: "0 0 1,15,L * *", want: []string{"FREQ=DAILY;BYMONTHDAY=1,15,-1;BYHOUR=0;BYMINUTE=0"}},
+		{schedule: "0 0 L-2 */3 *", want: []string{"FREQ=DAILY;BYMONTH=1,4,7,10;BYMONTHDAY=-3;BYHOUR=0;BYMINUTE=0"}},
+		{schedule: "30 8 ? * 5#3", want: []string{"FREQ=MONTHLY;BYDAY=3FR;BYHOUR=8;BYMINUTE=30"}},
+		{schedule: "30 8 ? * 5L", want: []string{"FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=8;BYMINUTE=30"}},
+		{schedule: "15,45 0 0 * * *", opts: []Option{WithSeconds()}, want: []string{"FREQ=DAILY;BYHOUR=0;BYMINUTE=0;BYSECOND=15,45"}},
+		{schedule: "* * * * * *", opts: []Option{WithSeconds()}, want: []string{"FREQ=SECONDLY"}},
+		// Days of the week counted within the month take a rule of their own
+		{
+			schedule: "0 12 * * 1,5#1",
+			want:     []string{"FREQ=DAILY;BYDAY=MO;BYHOUR=12;BYMINUTE=0", "FREQ=MONTHLY;BYDAY=1FR;BYHOUR=12;BYMINUTE=0"},
+		},
+		// Either day field may match
+		{
+			schedule: "0 0 1 * MON",
+			opts:     []Option{WithDayMatch(DayMatchOr)},
+			want:     []string{"FREQ=DAILY;BYMONTHDAY=1;BYHOUR=0;BYMINUTE=0", "FREQ=DAILY;BYDAY=MO;BYHOUR=0;BYMINUTE=0"},
+		},
+		{
+			schedule: "0 0 1 * *",
+			opts:     []Option{WithDayMatch(DayMatchOr)},
+			want:     []string{"FREQ=DAILY;BYMONTHDAY=1;BYHOUR=0;BYMINUTE=0"},
+		},
+		// A NotAfter bound ends every rule
+		{
+			schedule: "0 12 * * 1,5#1",
+			opts:     []Option{WithNotAfter(time.Date(2024, 6, 30, 12, 0, 0, 0, time.FixedZone("", 2*60*60)))},
+			want: []string{
+				"FREQ=DAILY;BYDAY=MO;BYHOUR=12;BYMINUTE=0;UNTIL=20240630T100000Z",
+				"FREQ=MONTHLY;BYDAY=1FR;BYHOUR=12;BYMINUTE=0;UNTIL=20240630T100000Z",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.schedule, func(t *testing.T) {
+			rules, err := MustParse(tt.schedule, tt.opts...).RRule()
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, rules)
+			}
+		})
+	}
+}
+
+func TestCron_RRule_Unrepresentable(t *testing.T) {
+	for _, schedule := range []*Cron{
+		MustParse("0 0 15W * ?"),
+		MustParse("0 0 LW * ?"),
+		MustParse("0 0 1 1 * 2030", WithYears()),
+	} {
+		t.Run(schedule.String(), func(t *testing.T) {
+			_, err := schedule.RRule()
+			assert.ErrorIs(t, err, UnrepresentableSchedule)
+		})
+	}
+}
+
+// TestCron_RRule_RoundTrip checks that the rules, read back with ParseRRule,
+// activate at exactly the times of the schedule they were written from
+func TestCron_RRule_RoundTrip(t *testing.T) {
+	dtstart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := dtstart.AddDate(2, 0, 0)
+	schedules := []*Cron{
+		MustParse("0 9 * * 1-5"),
+		MustParse("*/20 6,18 * * *"),
+		MustParse("0 0 29 2 *"),
+		MustParse("0 0 1,15,L-1 * *"),
+		MustParse("30 22 ? 1-6 5L,2#2"),
+		MustParse("0 12 * * 1,5#1"),
+		MustParse("0 0 13 * 5", WithDayMatch(DayMatchOr)),
+		MustParse("0 0 30 */2 ?"),
+	}
+	for _, schedule := range schedules {
+		t.Run(schedule.String(), func(t *testing.T) {
+			rules, err := schedule.RRule()
+			if !assert.NoError(t, err) {
+				return
+			}
+			var parsed []*Cron
+			for _, rule := range rules {
+				cron, err := ParseRRule(rule, dtstart)
+				if !assert.NoError(t, err) {
+					return
+				}
+				parsed = append(parsed, cron)
+			}
+
+			for from := dtstart.Add(-time.Second); from.Before(until); {
+				want := schedule.NextFrom(from)
+				var got time.Time
+				for _, cron := range parsed {
+					if next := cron.NextFrom(from); got.IsZero() || next.Before(got) {
+						got = next
+					}
+				}
+				if !assert.Equal(t, want, got, "after %v", from) {
+					return
+				}
+				from = want
+			}
+		})
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	// A Saturday
+	dtstart := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+	tests := []struct {
+		rule string
+		want []time.Time
+	}{
+		{
+			rule: "FREQ=DAILY",
+			want: []time.Time{
+				time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC),
+				time.Date(2023, 6, 18, 18, 23, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule: "RRULE:FREQ=WEEKLY;BYDAY=MO,WE;BYHOUR=9;BYMINUTE=0",
+			want: []time.Time{
+				time.Date(2023, 6, 19, 9, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 21, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule: "FREQ=WEEKLY",
+			want: []time.Time{
+				time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC),
+				time.Date(2023, 6, 24, 18, 23, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule: "FREQ=MONTHLY;BYDAY=2TU",
+			want: []time.Time{
+				time.Date(2023, 7, 11, 18, 23, 0, 0, time.UTC),
+				time.Date(2023, 8, 8, 18, 23, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule: "FREQ=MONTHLY;BYMONTHDAY=-1",
+			want: []time.Time{
+				time.Date(2023, 6, 30, 18, 23, 0, 0, time.UTC),
+				time.Date(2023, 7, 31, 18, 23, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule: "FREQ=MONTHLY;INTERVAL=3",
+			want: []time.Time{
+				time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC),
+				time.Date(2023, 9, 17, 18, 23, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule: "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			want: []time.Time{
+				time.Date(2023, 11, 23, 18, 23, 0, 0, time.UTC),
+				time.Date(2024, 11, 28, 18, 23, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule: "FREQ=YEARLY",
+			want: []time.Time{
+				time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC),
+				time.Date(2024, 6, 17, 18, 23, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule: "FREQ=HOURLY;INTERVAL=6;BYMINUTE=0",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 18, 6, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			rule: "freq=minutely;interval=20;byhour=9",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 9, 3, 0, 0, time.UTC),
+				time.Date(2023, 6, 18, 9, 23, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			cron, err := ParseRRule(tt.rule, dtstart)
+			if !assert.NoError(t, err) {
+				return
+			}
+			from := dtstart.Add(-time.Second)
+			for _, want := range tt.want {
+				from = cron.NextFrom(from)
+				assert.Equal(t, want, from)
+			}
+		})
+	}
+}
+
+func TestParseRRule_Until(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if !assert.NoError(t, err) {
+		return
+	}
+	dtstart := time.Date(2024, 3, 1, 9, 0, 0, 0, newYork)
+	tests := []struct {
+		until string
+		want  time.Time
+	}{
+		{until: "20240315T130000Z", want: time.Date(2024, 3, 15, 9, 0, 0, 0, newYork)},
+		{until: "20240315T090000", want: time.Date(2024, 3, 15, 9, 0, 0, 0, newYork)},
+		{until: "20240315", want: time.Date(2024, 3, 15, 23, 59, 59, 999999999, newYork)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.until, func(t *testing.T) {
+			cron, err := ParseRRule("FREQ=DAILY;UNTIL="+tt.until, dtstart)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.True(t, tt.want.Equal(cron.NotAfter()), "got %v", cron.NotAfter())
+			assert.True(t, time.Date(2024, 3, 15, 9, 0, 0, 0, newYork).Equal(cron.PrevBefore(time.Date(2024, 4, 1, 0, 0, 0, 0, newYork))))
+			assert.True(t, cron.NextFrom(time.Date(2024, 3, 15, 9, 0, 0, 0, newYork)).IsZero())
+		})
+	}
+}
+
+func TestParseRRule_ParseError(t *testing.T) {
+	dtstart := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+	tests := []struct {
+		rule  string
+		field string
+		want  string
+	}{
+		{rule: "BYHOUR=9", want: "FREQ is required"},
+		{rule: "FREQ=FORTNIGHTLY", field: "FREQ", want: `unknown frequency "FORTNIGHTLY"`},
+		{rule: "FREQ=DAILY;COUNT=10", field: "COUNT", want: "COUNT is not supported"},
+		{rule: "FREQ=DAILY;BYSETPOS=-1", field: "BYSETPOS", want: "BYSETPOS is not supported"},
+		{rule: "FREQ=DAILY;UNTIL=tomorrow", field: "UNTIL", want: `"TOMORROW" is not a date or a time`},
+		{rule: "FREQ=DAILY;INTERVAL=2", field: "INTERVAL", want: "an interval of 2 is not supported with FREQ=DAILY"},
+		{rule: "FREQ=MINUTELY;INTERVAL=7", field: "INTERVAL", want: "an interval of 7 does not divide an hour evenly"},
+		{rule: "FREQ=DAILY;BYHOUR=24", field: "BYHOUR", want: `"24" is outside the range 0-23`},
+		{rule: "FREQ=DAILY;BYDAY=2TU", field: "BYDAY", want: `"2TU" is only supported with FREQ=MONTHLY`},
+		{rule: "FREQ=MONTHLY;BYDAY=-2TU", field: "BYDAY", want: `"-2TU" counts other than 1-5 or -1`},
+		{rule: "FREQ=WEEKLY;BYMONTHDAY=1", field: "BYMONTHDAY", want: "BYMONTHDAY is not allowed with FREQ=WEEKLY"},
+		{rule: "FREQ=DAILY;FREQ=DAILY", field: "FREQ", want: "FREQ is given more than once"},
+		{rule: "FREQ=DAILY;BYHOUR", want: `expected NAME=value, got "BYHOUR"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			_, err := ParseRRule(tt.rule, dtstart)
+			var parseErr *ParseError
+			if assert.True(t, errors.As(err, &parseErr), "got %v", err) {
+				assert.Equal(t, tt.field, parseErr.Field)
+				assert.Contains(t, parseErr.Err.Error(), tt.want)
+			}
+		})
+	}
+
+	_, err := ParseRRule("", dtstart)
+	assert.ErrorIs(t, err, EmptyCronSchedule)
+}
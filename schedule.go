@@ -0,0 +1,22 @@
+package cron
+
+import (
+	"time"
+)
+
+/*
+Schedule is the set of activations of a schedule, whichever syntax it was
+written in. Parse returns a *Cron, ParseEventBridge may also return a *Rate
+*/
+type Schedule interface {
+	// NextFrom returns the next activation after from, or the zero time if there is none
+	NextFrom(from time.Time) time.Time
+	// PrevBefore returns the previous activation before before, or the zero time if there is none
+	PrevBefore(before time.Time) time.Time
+	// Next returns the next activation after now
+	Next() time.Time
+	// Prev returns the previous activation before now
+	Prev() time.Time
+	// Now reports whether the schedule activates in the current minute
+	Now() bool
+}
@@ -3,7 +3,7 @@ module github.com/frisbm/cron/scheduler
 go 1.20
 
 require (
-	github.com/frisbm/cron v1.1.0
+	github.com/frisbm/cron v1.2.0
 	golang.org/x/sync v0.3.0
 )
 
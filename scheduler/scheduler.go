@@ -37,6 +37,7 @@ type Task struct {
 
 type Scheduler struct {
 	tasks []*Task
+	clock cron.Clock
 }
 
 func NewTask(cron *cron.Cron, fn func(ctx context.Context) error) *Task {
@@ -47,7 +48,14 @@ func NewTask(cron *cron.Cron, fn func(ctx context.Context) error) *Task {
 }
 
 func NewScheduler() *Scheduler {
-	return &Scheduler{}
+	return &Scheduler{
+		clock: cron.SystemClock,
+	}
+}
+
+func (s *Scheduler) UseClock(clock cron.Clock) *Scheduler {
+	s.clock = clock
+	return s
 }
 
 func (s *Scheduler) AddTask(task *Task) *Scheduler {
@@ -64,7 +72,7 @@ func (s *Scheduler) Run(ctx context.Context) error {
 	for _, task := range s.tasks {
 		task := task
 		g.Go(func() error {
-			return runTaskSchedule(ctx, task)
+			return runTaskSchedule(ctx, task, s.clock)
 		})
 	}
 
@@ -81,27 +89,38 @@ func (s *Scheduler) Run(ctx context.Context) error {
 	return nil
 }
 
-func runTaskSchedule(ctx context.Context, task *Task) error {
-	ticker := time.NewTicker(minuteTick())
-	defer ticker.Stop()
-
+func runTaskSchedule(ctx context.Context, task *Task, clock cron.Clock) error {
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case <-ticker.C:
-			if task.cron.Now() {
+		case <-after(clock, minuteTick(clock)):
+			// The task is due when its next activation after the previous
+			// minute is the current minute of the scheduler's clock
+			now := clock.Now().Truncate(time.Minute)
+			if task.cron.NextFrom(now.Add(-1 * time.Minute)).Equal(now) {
 				if err := task.fn(ctx); err != nil {
 					return fmt.Errorf("task execution failed: %w", err)
 				}
 			}
-			ticker.Reset(minuteTick())
 		}
 	}
 }
 
-func minuteTick() time.Duration {
-	return time.Second * time.Duration(60-time.Now().Second())
+// after waits on the clock when it can, as a cron.FakeClock can, so that a scheduler
+// given a simulated clock runs its tasks as that clock is moved on
+func after(clock cron.Clock, d time.Duration) <-chan time.Time {
+	if waiter, ok := clock.(interface {
+		After(d time.Duration) <-chan time.Time
+	}); ok {
+		return waiter.After(d)
+	}
+	return time.After(d)
+}
+
+func minuteTick(clock cron.Clock) time.Duration {
+	now := clock.Now()
+	return now.Truncate(time.Minute).Add(time.Minute).Sub(now)
 }
 
 func handleShutdownSignal(ctx context.Context, cancel context.CancelFunc) error {
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"github.com/frisbm/cron"
+	"testing"
+	"time"
+)
+
+func TestScheduler_FakeClock(t *testing.T) {
+	clock := cron.NewFakeClock(time.Date(2024, 1, 1, 9, 59, 58, 0, time.UTC))
+	ran := make(chan time.Time, 1)
+	task := NewTask(cron.MustParse("0 10 * * *"), func(ctx context.Context) error {
+		ran <- clock.Now()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- NewScheduler().UseClock(clock).AddTask(task).Run(ctx)
+	}()
+
+	// Move the simulated time on a second at a time until the task runs, no
+	// real minute passes
+	var at time.Time
+	for i := 0; i < 600 && at.IsZero(); i++ {
+		clock.Advance(time.Second)
+		select {
+		case at = <-ran:
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC); !at.Truncate(time.Minute).Equal(want) {
+		t.Fatalf("task ran at %v, want within the minute of %v", at, want)
+	}
+}
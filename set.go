@@ -4,25 +4,84 @@ import (
 	"golang.org/x/exp/constraints"
 )
 
-type set[T constraints.Ordered] struct {
-	items map[T]struct{}
+// set is a bitset of small unsigned values. Every cron field fits
+// within 0-63, so a single word holds a whole field without allocating.
+type set[T constraints.Unsigned] struct {
+	bits uint64
 }
 
-func newSet[T constraints.Ordered](capacity int, items ...T) set[T] {
-	s := set[T]{
-		items: make(map[T]struct{}, capacity),
-	}
+func newSet[T constraints.Unsigned](items ...T) set[T] {
+	s := set[T]{}
 	s.add(items...)
 	return s
 }
 
-func (s set[T]) add(items ...T) {
+func (s *set[T]) add(items ...T) {
 	for _, item := range items {
-		s.items[item] = struct{}{}
+		s.bits |= 1 << item
+	}
+}
+
+// addRange adds every value between start and end (inclusive) that is
+// a multiple of step away from origin
+func (s *set[T]) addRange(start, end, step, origin T) {
+	// Find the first value GTE to start that is a multiple of step from origin
+	first := start + (step-(start-origin)%step)%step
+	for i := first; i <= end; i += step {
+		s.bits |= 1 << i
 	}
 }
 
 func (s set[T]) contains(key T) bool {
-	_, ok := s.items[key]
-	return ok
+	return s.bits&(1<<key) != 0
+}
+
+const (
+	minYear = 1970
+	maxYear = 2199
+)
+
+// yearSet is a bitset of the years minYear through maxYear,
+// the zero value places no restriction on the year
+type yearSet struct {
+	bits [(maxYear - minYear + 64) / 64]uint64
+}
+
+// addRange adds every year between start and end (inclusive), stepping from start
+func (s *yearSet) addRange(start, end, step int) {
+	for year := start; year <= end; year += step {
+		i := year - minYear
+		s.bits[i/64] |= 1 << (i % 64)
+	}
+}
+
+func (s yearSet) contains(year int) bool {
+	if s == (yearSet{}) {
+		return true
+	}
+	if year < minYear || year > maxYear {
+		return false
+	}
+	i := year - minYear
+	return s.bits[i/64]&(1<<(i%64)) != 0
+}
+
+// last returns the last year in the set, or zero when the set has no restriction
+func (s yearSet) last() int {
+	for year := maxYear; year >= minYear && s != (yearSet{}); year-- {
+		if s.contains(year) {
+			return year
+		}
+	}
+	return 0
+}
+
+// first returns the first year in the set, or zero when the set has no restriction
+func (s yearSet) first() int {
+	for year := minYear; year <= maxYear && s != (yearSet{}); year++ {
+		if s.contains(year) {
+			return year
+		}
+	}
+	return 0
 }
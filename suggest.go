@@ -0,0 +1,142 @@
+package cron
+
+import (
+	"strconv"
+	"time"
+)
+
+/*
+Suggest proposes a schedule of the frequency, written as for ParseNatural such as "hourly"
+or "daily between 1am and 5am", that activates together with the existing schedules the
+least from from until to, and then next to them the least. What the frequency leaves open
+is chosen: the minute of an hourly schedule, the offset of "every 15 minutes", the hour and
+minute of a daily one and the day of the week of a weekly one. The hours of a schedule that
+activates once a day or less often are where its time is chosen from.
+
+The options apply as for ParseNatural, the times are chosen in the location of WithLocation.
+Of the times that are as quiet as each other, the earliest is suggested
+*/
+func Suggest(existing []*Cron, frequency string, from, to time.Time, opts ...Option) (*Cron, error) {
+	p, err := parseNaturalSchedule(frequency)
+	if err != nil {
+		return nil, err
+	}
+	s := &p.schedule
+	daily := s.unit != secondUnit && s.unit != minuteUnit && s.unit != hourUnit
+	hours := []int{0, 23}
+	if daily && len(s.times) == 0 && s.hourRange != nil {
+		hours, s.hourRange = s.hourRange, nil
+	}
+	chooseWeekday := s.unit == weekUnit && len(s.weekdays) == 0 && len(s.days) == 0
+	parts, err := s.fields(p)
+	if err != nil {
+		return nil, err
+	}
+	heatmap, err := NewHeatmap(existing, from, to, newCron(opts).loc)
+	if err != nil {
+		return nil, err
+	}
+
+	// The candidates are the fields of the schedule with each choice made in turn,
+	// the minute field is at 0 and the seconds field, when there is one, before it
+	first := len(parts) - 5
+	candidates := [][]string{parts}
+	vary := func(field int, values []string) {
+		var varied [][]string
+		for _, candidate := range candidates {
+			for _, value := range values {
+				next := append([]string(nil), candidate...)
+				next[first+field] = value
+				varied = append(varied, next)
+			}
+		}
+		candidates = varied
+	}
+	switch {
+	case s.unit == secondUnit && s.interval > 1:
+		vary(-1, suggestOffsets(s.interval))
+	case s.unit == minuteUnit && s.interval > 1:
+		vary(0, suggestOffsets(s.interval))
+	case s.unit == hourUnit:
+		if s.pastMinute < 0 {
+			vary(0, suggestValues(0, 59, 60))
+		}
+		if s.interval > 1 && s.hourRange == nil {
+			vary(1, suggestOffsets(s.interval))
+		}
+	case daily && len(s.times) == 0:
+		vary(1, suggestValues(hours[0], hours[1], 24))
+		vary(0, suggestValues(0, 59, 60))
+		if chooseWeekday {
+			vary(4, suggestValues(0, 6, 7))
+		}
+	}
+
+	var best *Cron
+	bestSame, bestNear := 0, 0
+	for _, candidate := range candidates {
+		cron, err := p.cron(candidate, opts)
+		if err != nil {
+			return nil, err
+		}
+		same, near := heatmap.load(cron)
+		if best == nil || same < bestSame || same == bestSame && near < bestNear {
+			best, bestSame, bestNear = cron, same, near
+		}
+	}
+	return best, nil
+}
+
+// suggestOffsets returns the steps of the interval from each of its offsets, i.e. 5/15
+func suggestOffsets(interval int) []string {
+	offsets := []string{"*/" + strconv.Itoa(interval)}
+	for offset := 1; offset < interval; offset++ {
+		offsets = append(offsets, strconv.Itoa(offset)+"/"+strconv.Itoa(interval))
+	}
+	return offsets
+}
+
+// suggestValues returns each value from start to end, wrapping around to 0 at size
+// when start is after end, i.e. the hours 22-1
+func suggestValues(start, end, size int) []string {
+	var values []string
+	for value := start; ; value = (value + 1) % size {
+		values = append(values, strconv.Itoa(value))
+		if value == end {
+			return values
+		}
+	}
+}
+
+// load returns the activations of the heatmap in the minutes the schedule activates in
+// on the days of the week it does, and in the minutes either side of those
+func (h *Heatmap) load(c *Cron) (same, near int) {
+	weekdays := c.weekday.bits | c.lastWeekdays.bits
+	for nth := uint8(0); nth < 35; nth++ {
+		if c.nthWeekdays.contains(nth) {
+			weekdays |= 1 << (nth % 7)
+		}
+	}
+	count := func(minute int) int {
+		minute = (minute + 7*24*60) % (7 * 24 * 60)
+		return h.Counts[minute/(24*60)][minute/60%24][minute%60]
+	}
+	for weekday := 0; weekday < 7; weekday++ {
+		if weekdays&(1<<weekday) == 0 {
+			continue
+		}
+		for hour := 0; hour < 24; hour++ {
+			if !c.hour.contains(uint8(hour)) {
+				continue
+			}
+			for minute := 0; minute < 60; minute++ {
+				if c.minute.contains(uint8(minute)) {
+					at := (weekday*24+hour)*60 + minute
+					same += count(at)
+					near += count(at-1) + count(at+1)
+				}
+			}
+		}
+	}
+	return same, near
+}
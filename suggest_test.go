@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  []string
+		frequency string
+		want      string
+	}{
+		{
+			name:      "hourly avoids the busy minutes and those next to them",
+			existing:  []string{"0 * * * *", "30 * * * *", "2 * * * *"},
+			frequency: "hourly",
+			want:      "4 * * * *",
+		},
+		{
+			name:      "offset of every 15 minutes",
+			existing:  []string{"*/15 * * * *", "5-59/15 * * * *"},
+			frequency: "every 15 minutes",
+			want:      "2,17,32,47 * * * *",
+		},
+		{
+			name:      "offset of every 6 hours",
+			existing:  []string{"* 0-2 * * *"},
+			frequency: "every 6 hours",
+			want:      "0 4,10,16,22 * * *",
+		},
+		{
+			name:      "every 2 hours from an odd hour",
+			existing:  []string{"0-1 * * * *"},
+			frequency: "every 2 hours between 9am and 5pm",
+			want:      "3 9,11,13,15 * * *",
+		},
+		{
+			name:      "daily within its hours, away from the busy minutes",
+			existing:  []string{"* 1-3 * * *", "*/2 4 * * *"},
+			frequency: "daily between 1am and 5am",
+			want:      "59 4 * * *",
+		},
+		{
+			name:      "daily across midnight",
+			existing:  []string{"* 22,23 * * *"},
+			frequency: "daily between 10pm and 2am",
+			want:      "1 0 * * *",
+		},
+		{
+			name:      "day of a weekly schedule",
+			existing:  []string{"* * * * 0"},
+			frequency: "weekly",
+			want:      "0 0 * * 2",
+		},
+		{
+			name:      "on weekdays only",
+			existing:  []string{"0 0 * * 1-5", "2 0 * * 0,6"},
+			frequency: "weekdays between midnight and 2am",
+			want:      "2 0 * * 1-5",
+		},
+		{
+			name:      "nothing left to choose",
+			existing:  []string{"0 9 * * *"},
+			frequency: "daily at 9am",
+			want:      "0 9 * * *",
+		},
+		{
+			name:      "no existing load",
+			frequency: "daily between 1am and 5am",
+			want:      "0 1 * * *",
+		},
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var existing []*Cron
+			for _, schedule := range tt.existing {
+				existing = append(existing, MustParse(schedule))
+			}
+			cron, err := Suggest(existing, tt.frequency, from, from.AddDate(0, 0, 14))
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, cron.String())
+			}
+		})
+	}
+}
+
+func TestSuggest_Location(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if !assert.NoError(t, err) {
+		return
+	}
+	// 8am in UTC is 9am in Berlin in January
+	existing := []*Cron{MustParse("* 8 * * *")}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cron, err := Suggest(existing, "daily between 9am and 11am", from, from.AddDate(0, 0, 7), WithLocation(berlin))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1 10 * * *", cron.String())
+		assert.Equal(t, time.Date(2024, 1, 1, 10, 1, 0, 0, berlin), cron.NextFrom(from))
+	}
+}
+
+func TestSuggest_Error(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := Suggest(nil, "every 7 minutes", from, from.AddDate(0, 0, 7))
+	assert.ErrorIs(t, err, InvalidCronSchedule)
+	_, err = Suggest(nil, "", from, from.AddDate(0, 0, 7))
+	assert.ErrorIs(t, err, EmptyCronSchedule)
+	_, err = Suggest([]*Cron{nil}, "hourly", from, from.AddDate(0, 0, 7))
+	assert.EqualError(t, err, "schedule 0 is nil")
+	_, err = Suggest(nil, "hourly", from, from)
+	assert.EqualError(t, err, "heatmap window must end after it starts")
+}
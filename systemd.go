@@ -0,0 +1,479 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var systemd = dialect{
+	noWrap: true,
+}
+
+// calendarShorthands are the expressions the shorthands of systemd calendar events stand for
+var calendarShorthands = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"weekly":       "Mon *-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+}
+
+// maxLastDays is the most days systemd counts from the end of the month
+const maxLastDays = 28
+
+// calendarWeekdays are the days of the week in the order systemd ranges them, from Monday
+var calendarWeekdays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+}
+
+/*
+ParseOnCalendar takes a systemd calendar event (Mon..Fri *-*-* 09:00:00), as
+written in the OnCalendar= setting of a timer, and returns a Cron object if
+the expression is valid; otherwise, it returns an error. Options may be given
+to change how the schedule is calculated, a time zone at the end of the
+expression takes the place of WithLocation.
+
+The expression is made up of an optional day of week, date and time, followed
+by an optional time zone:
+
+* Day of week: Mon..Sun or Monday..Sunday (, ..)
+
+* Date: [1970-2199]-[1-12]-[1-31] (* , .. /), the year is optional and the
+day may be counted [1-28] from the end of the month with ~ in place of - (i.e. *-*~01)
+
+* Time: [0-23]:[0-59]:[0-59] (* , .. /), the seconds are optional
+
+A missing date is *-*-*, a missing time is 00:00:00 and the shorthands minutely,
+hourly, daily, weekly, monthly, quarterly, semiannually, yearly and annually
+may stand in for the whole expression. Fractional seconds are not supported.
+*/
+func ParseOnCalendar(expression string, opts ...Option) (*Cron, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, EmptyCronSchedule
+	}
+
+	conf := newConfig(opts)
+	cron := conf.Cron
+	cron.seconds = true
+
+	var tokens [maxFields]string
+	var offsets [maxFields]int
+	count := splitFields(expression, &tokens, &offsets)
+	if count > 4 {
+		return nil, &ParseError{
+			Schedule: expression,
+			Err:      errors.New("expected a day of week, date, time and time zone at most"),
+		}
+	}
+
+	// A shorthand stands for a whole expression, only a time zone may follow it
+	if shorthand, ok := calendarShorthands[strings.ToLower(tokens[0])]; ok {
+		if count > 2 {
+			return nil, &ParseError{
+				Schedule: expression,
+				Err:      fmt.Errorf("only a time zone may follow %q", tokens[0]),
+			}
+		}
+		zone, zoneOffset := tokens[1], offsets[1]
+		offset := offsets[0]
+		count = splitFields(shorthand, &tokens, &offsets)
+		for i := 0; i < count; i++ {
+			offsets[i] = offset
+		}
+		if zone != "" {
+			tokens[count], offsets[count] = zone, zoneOffset
+			count++
+		}
+	}
+
+	weekdays, date, clock := "", "*-*-*", "00:00:00"
+	var weekdayOffset, dateOffset, clockOffset int
+	i := 0
+	if i < count && isLetter(tokens[i][0]) {
+		weekdays, weekdayOffset = tokens[i], offsets[i]
+		i++
+	}
+	if i < count && strings.ContainsAny(tokens[i], "-~") && !isLetter(tokens[i][0]) {
+		date, dateOffset = tokens[i], offsets[i]
+		i++
+	}
+	if i < count && strings.Contains(tokens[i], ":") && !isLetter(tokens[i][0]) {
+		clock, clockOffset = tokens[i], offsets[i]
+		i++
+	}
+	if i == count-1 && isLetter(tokens[i][0]) {
+		loc, err := time.LoadLocation(tokens[i])
+		if err != nil {
+			return nil, &ParseError{Schedule: expression, Offset: offsets[i], Err: err}
+		}
+		cron.loc = loc
+		i++
+	}
+	if i < count {
+		return nil, &ParseError{
+			Schedule: expression,
+			Offset:   offsets[i],
+			Err:      fmt.Errorf("unexpected %q", tokens[i]),
+		}
+	}
+
+	if err := parseCalendarWeekdays(cron, weekdays); err != nil {
+		return nil, &ParseError{Schedule: expression, Field: string(weekday), Offset: weekdayOffset, Err: err}
+	}
+	if err := parseCalendarDate(conf, expression, date, dateOffset); err != nil {
+		return nil, err
+	}
+	if err := parseCalendarTime(conf, expression, clock, clockOffset); err != nil {
+		return nil, err
+	}
+	return cron, nil
+}
+
+// parseCalendarWeekdays parses the day of week of a calendar event, every day when it is empty
+func parseCalendarWeekdays(cron *Cron, weekdays string) error {
+	cron.weekdayStar = weekdays == ""
+	if weekdays == "" {
+		cron.weekday.addRange(0, 6, 1, 0)
+		return nil
+	}
+
+	for more := true; more; {
+		var item string
+		item, weekdays, more = strings.Cut(weekdays, ",")
+		lower, upper, isRange := strings.Cut(item, "..")
+		start, err := calendarWeekday(lower)
+		if err != nil {
+			return err
+		}
+		end := start
+		if isRange {
+			end, err = calendarWeekday(upper)
+			if err != nil {
+				return err
+			}
+			if start > end {
+				return errors.New("range min cannot be greater than range max")
+			}
+		}
+		for _, weekday := range calendarWeekdays[start : end+1] {
+			cron.weekday.add(uint8(weekday))
+		}
+	}
+	return nil
+}
+
+// calendarWeekday returns the index within calendarWeekdays of a day of the week, by its short or full name
+func calendarWeekday(name string) (int, error) {
+	for i, weekday := range calendarWeekdays {
+		if strings.EqualFold(name, weekday.String()) || strings.EqualFold(name, weekday.String()[:3]) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day of the week %q", name)
+}
+
+// parseCalendarDate parses the [year-]month-day date of a calendar event, where the
+// day is counted from the end of the month when it follows a ~
+func parseCalendarDate(cron *config, expression, date string, offset int) error {
+	sep := strings.LastIndexAny(date, "-~")
+	head, days := date[:sep], date[sep+1:]
+	fromEnd := date[sep] == '~'
+
+	months, monthOffset := head, offset
+	if years, rest, ok := strings.Cut(head, "-"); ok {
+		months, monthOffset = rest, offset+len(years)+1
+		if years != "*" {
+			cronPart, err := calendarPart(calendarYears(years))
+			if err == nil {
+				cron.year, err = systemd.parseYearPart(cronPart)
+			}
+			if err != nil {
+				return &ParseError{Schedule: expression, Field: string(year), Offset: offset, Err: err}
+			}
+			cron.years = true
+		}
+	}
+
+	cronPart, err := calendarPart(months)
+	if err == nil {
+		cron.month, err = systemd.parseCronPart(cron, cronPart, 1, 12, month)
+	}
+	if err != nil {
+		return &ParseError{Schedule: expression, Field: string(month), Offset: monthOffset, Err: err}
+	}
+
+	cron.dayStar = days == "*"
+	switch {
+	case fromEnd && days != "*":
+		var lastDays set[uint8]
+		lastDays, err = parseCalendarLastDays(days)
+		cron.lastDays.bits = lastDays.bits >> 1
+	default:
+		cronPart, err = calendarPart(days)
+		if err == nil {
+			cron.day, err = systemd.parseCronPart(cron, cronPart, 1, 31, day)
+		}
+	}
+	if err != nil {
+		return &ParseError{Schedule: expression, Field: string(day), Offset: offset + sep + 1, Err: err}
+	}
+	return nil
+}
+
+// parseCalendarLastDays parses a day counted from the end of the month, where 1 is
+// the last day. A repetition counts down towards the end of the month, i.e. ~07/2
+// is the 7th, 5th, 3rd and last day from the end
+func parseCalendarLastDays(days string) (set[uint8], error) {
+	lastDays := newSet[uint8]()
+	if strings.Contains(days, "-") {
+		return lastDays, errors.New("ranges are written as a..b")
+	}
+	for more := true; more; {
+		var item string
+		item, days, more = strings.Cut(days, ",")
+
+		base, stepPart, hasStep := strings.Cut(item, "/")
+		step := uint8(1)
+		if hasStep {
+			var err error
+			step, err = aToi8(stepPart, 1, maxLastDays)
+			if err != nil {
+				return lastDays, err
+			}
+		}
+
+		lower, upper, isRange := strings.Cut(base, "..")
+		start, err := aToi8(lower, 1, maxLastDays)
+		if err != nil {
+			return lastDays, err
+		}
+		end := start
+		if isRange {
+			end, err = aToi8(upper, 1, maxLastDays)
+			if err != nil {
+				return lastDays, err
+			}
+			if start > end {
+				return lastDays, errors.New("range min cannot be greater than range max")
+			}
+		} else if hasStep {
+			start, end = 1, start
+		}
+		for v := int(end); v >= int(start); v -= int(step) {
+			lastDays.add(uint8(v))
+		}
+	}
+	return lastDays, nil
+}
+
+// parseCalendarTime parses the hour:minute[:second] time of a calendar event
+func parseCalendarTime(cron *config, expression, clock string, offset int) error {
+	fields := strings.Split(clock, ":")
+	if len(fields) == 2 {
+		fields = append(fields, "00")
+	}
+	if len(fields) != 3 {
+		return &ParseError{Schedule: expression, Offset: offset, Err: errors.New("expected a time of hour:minute[:second]")}
+	}
+
+	parts := []partType{hour, minute, second}
+	maxes := []uint8{23, 59, 59}
+	sets := []*set[uint8]{&cron.hour, &cron.minute, &cron.second}
+	for i, field := range fields {
+		cronPart, err := calendarPart(field)
+		if err == nil {
+			*sets[i], err = systemd.parseCronPart(cron, cronPart, 0, maxes[i], parts[i])
+		}
+		if err != nil {
+			return &ParseError{Schedule: expression, Field: string(parts[i]), Offset: offset, Err: err}
+		}
+		offset += len(field) + 1
+	}
+	return nil
+}
+
+// calendarPart rewrites a component of a calendar event as a cron part,
+// systemd writes ranges as a..b and does not step from *
+func calendarPart(component string) (string, error) {
+	for i := 0; i < len(component); i++ {
+		c := component[i]
+		if !isDigit(c) && c != '*' && c != ',' && c != '/' && c != '.' {
+			return "", fmt.Errorf("unexpected %q", c)
+		}
+	}
+	cronPart := strings.ReplaceAll(component, "..", "-")
+	if strings.Contains(cronPart, ".") {
+		return "", errors.New("ranges are written as a..b, fractions are not supported")
+	}
+	if strings.Contains(cronPart, "*/") {
+		return "", errors.New("a repetition must start from a value")
+	}
+	return cronPart, nil
+}
+
+// calendarYears expands the two digit years of a calendar event, 70-99
+// are the years 1970-1999 and 00-69 are the years 2000-2069
+func calendarYears(years string) string {
+	var b strings.Builder
+	for i := 0; i < len(years); {
+		end := i
+		for end < len(years) && isDigit(years[end]) {
+			end++
+		}
+		if end-i == 2 && (i == 0 || years[i-1] != '/') {
+			year, _ := strconv.Atoi(years[i:end])
+			if year < 70 {
+				year += 100
+			}
+			b.WriteString(strconv.Itoa(1900 + year))
+			i = end
+			continue
+		}
+		if end == i {
+			end++
+		}
+		b.WriteString(years[i:end])
+		i = end
+	}
+	return b.String()
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+/*
+OnCalendar returns the schedule as a systemd calendar event, for the OnCalendar=
+setting of a timer. The location of the Cron is written at the end, unless it is
+time.Local. An error matching UnrepresentableSchedule is returned for the W, L and
+# rules other than days counted from the end of the month, for days that may
+match either the day of the month or the day of the week, for the days
+excluded by a calendar, for business days and for the bounds of WithNotBefore
+and WithNotAfter
+*/
+func (c *Cron) OnCalendar() (string, error) {
+	if len(c.calendars) > 0 {
+		return "", fmt.Errorf("%w as a calendar event: excludes the days of a calendar", UnrepresentableSchedule)
+	}
+	if c.businessDays {
+		return "", fmt.Errorf("%w as a calendar event: keeps to business days", UnrepresentableSchedule)
+	}
+	if !c.notBefore.IsZero() || !c.notAfter.IsZero() {
+		return "", fmt.Errorf("%w as a calendar event: is bounded to a window", UnrepresentableSchedule)
+	}
+	if c.lastWeekday || c.nearestWeekdays.bits != 0 || c.lastWeekdays.bits != 0 || c.nthWeekdays.bits != 0 {
+		return "", fmt.Errorf("%w as a calendar event: uses the W, L or # rules", UnrepresentableSchedule)
+	}
+	if c.lastDays.bits != 0 && c.day.bits != 0 {
+		return "", fmt.Errorf("%w as a calendar event: mixes days from the start and the end of the month", UnrepresentableSchedule)
+	}
+	if c.lastDays.bits>>maxLastDays != 0 {
+		return "", fmt.Errorf("%w as a calendar event: counts more than %d days from the end of the month", UnrepresentableSchedule, maxLastDays)
+	}
+
+	days := "-" + formatCalendarPart(formatCronPart(c.day, 1, 31, true), 1)
+	weekdays := formatCalendarWeekdays(c.weekday)
+	if c.lastDays.bits != 0 {
+		days = formatCalendarLastDays(set[uint8]{bits: c.lastDays.bits << 1})
+	}
+	if c.dayMatch == DayMatchOr && !c.dayStar && !c.weekdayStar {
+		if days != "-*" && weekdays != "" {
+			return "", fmt.Errorf("%w as a calendar event: either of the day fields may match", UnrepresentableSchedule)
+		}
+		// Either field matching every day makes every day match
+		days, weekdays = "-*", ""
+	}
+
+	years := "*"
+	if c.years {
+		years = formatCalendarPart(formatYearPart(c.year), minYear)
+	}
+
+	var b strings.Builder
+	if weekdays != "" {
+		b.WriteString(weekdays + " ")
+	}
+	b.WriteString(years + "-" + formatCalendarPart(formatCronPart(c.month, 1, 12, true), 1) + days + " ")
+	b.WriteString(formatCalendarPart(formatCronPart(c.hour, 0, 23, true), 0) + ":")
+	b.WriteString(formatCalendarPart(formatCronPart(c.minute, 0, 59, true), 0) + ":")
+	b.WriteString(formatCalendarPart(formatCronPart(c.second, 0, 59, true), 0))
+	if c.loc != time.Local {
+		b.WriteString(" " + c.loc.String())
+	}
+	return b.String(), nil
+}
+
+// formatCalendarPart rewrites a cron part as a component of a calendar event, with
+// ranges written as a..b, steps from the first value of the field and values of two digits
+func formatCalendarPart(cronPart string, min int) string {
+	cronPart = stepFromValue(cronPart, min)
+
+	var b strings.Builder
+	for i := 0; i < len(cronPart); i++ {
+		switch {
+		case cronPart[i] == '-':
+			b.WriteString("..")
+		case isDigit(cronPart[i]) && (i == 0 || cronPart[i-1] == ',' || cronPart[i-1] == '-') &&
+			(i+1 == len(cronPart) || !isDigit(cronPart[i+1])):
+			// Values have two digits, the steps that follow a / are left as they are
+			b.WriteByte('0')
+			b.WriteByte(cronPart[i])
+		default:
+			b.WriteByte(cronPart[i])
+		}
+	}
+	return b.String()
+}
+
+// formatCalendarLastDays renders days counted from the end of the month, where 1 is the last day
+func formatCalendarLastDays(lastDays set[uint8]) string {
+	days := formatCronPart(lastDays, 1, maxLastDays, false)
+	if step, ok := strings.CutPrefix(days, "*/"); ok {
+		// A repetition counts down towards the end of the month, so it starts from the furthest day
+		furthest := uint8(maxLastDays)
+		for !lastDays.contains(furthest) {
+			furthest--
+		}
+		return "~" + formatCalendarPart(strconv.Itoa(int(furthest)), 1) + "/" + step
+	}
+	return "~" + formatCalendarPart(days, 1)
+}
+
+// formatCalendarWeekdays renders the days of the week as systemd ranges them, from
+// Monday, it is empty when every day of the week is included
+func formatCalendarWeekdays(weekdays set[uint8]) string {
+	if weekdays.bits == newSet[uint8](0, 1, 2, 3, 4, 5, 6).bits {
+		return ""
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(calendarWeekdays); i++ {
+		if !weekdays.contains(uint8(calendarWeekdays[i])) {
+			continue
+		}
+		end := i
+		for end+1 < len(calendarWeekdays) && weekdays.contains(uint8(calendarWeekdays[end+1])) {
+			end++
+		}
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(calendarWeekdays[i].String()[:3])
+		if end > i {
+			b.WriteString(".." + calendarWeekdays[end].String()[:3])
+		}
+		i = end
+	}
+	return b.String()
+}
@@ -0,0 +1,268 @@
+package cron
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// TestParseOnCalendar follows the examples of systemd.time, the activations
+// were checked against systemd-analyze calendar
+func TestParseOnCalendar(t *testing.T) {
+	from := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if !assert.NoError(t, err) {
+		return
+	}
+	tests := []struct {
+		name       string
+		expression string
+		want       []time.Time
+	}{
+		{
+			name:       "weekdays at 9am",
+			expression: "Mon..Fri *-*-* 09:00:00",
+			want: []time.Time{
+				time.Date(2023, 6, 19, 9, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 20, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "weekly",
+			expression: "weekly",
+			want: []time.Time{
+				time.Date(2023, 6, 19, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 26, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "first of the month without seconds",
+			expression: "*-*-01 00:00",
+			want: []time.Time{
+				time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "time only",
+			expression: "9:00",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "full weekday names in any case",
+			expression: "MONDAY,friday 10:00",
+			want: []time.Time{
+				time.Date(2023, 6, 19, 10, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 23, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "weekend",
+			expression: "Sat..Sun 12:00",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 12, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 24, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every 15 minutes",
+			expression: "*:0/15",
+			want: []time.Time{
+				time.Date(2023, 6, 17, 18, 30, 0, 0, time.UTC),
+				time.Date(2023, 6, 17, 18, 45, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every half hour during working hours",
+			expression: "9..17:0/30",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 9, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 18, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every other day of the first five",
+			expression: "*-*-1..5/2",
+			want: []time.Time{
+				time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "third last day of February",
+			expression: "*-02~03",
+			want: []time.Time{
+				time.Date(2024, 2, 27, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 2, 26, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "last Monday of May",
+			expression: "Mon *-05~07/1",
+			want: []time.Time{
+				time.Date(2024, 5, 27, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 5, 26, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "every other day from the end of the month",
+			expression: "*-*~07/2",
+			want: []time.Time{
+				time.Date(2023, 6, 24, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 26, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 28, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 7, 25, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "range of years",
+			expression: "2024..2026-01-01",
+			want: []time.Time{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				{},
+			},
+		},
+		{
+			name:       "two digit year",
+			expression: "24-01-01",
+			want: []time.Time{
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				{},
+			},
+		},
+		{
+			name:       "quarterly",
+			expression: "quarterly",
+			want: []time.Time{
+				time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "time zone",
+			expression: "*-*-* 9:00 Europe/Berlin",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 9, 0, 0, 0, berlin),
+				time.Date(2023, 6, 19, 9, 0, 0, 0, berlin),
+			},
+		},
+		{
+			name:       "shorthand with a time zone",
+			expression: "daily Europe/Berlin",
+			want: []time.Time{
+				time.Date(2023, 6, 18, 0, 0, 0, 0, berlin),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseOnCalendar(tt.expression)
+			if !assert.NoError(t, err) {
+				return
+			}
+			next := from
+			for _, want := range tt.want {
+				next = cron.NextFrom(next)
+				assert.True(t, want.Equal(next), "want %s, got %s", want, next)
+			}
+		})
+	}
+}
+
+func TestParseOnCalendar_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "empty", expression: " "},
+		{name: "unknown weekday", expression: "Mo 12:00"},
+		{name: "weekday range backwards", expression: "Sun..Mon 12:00"},
+		{name: "hour range backwards", expression: "22..2:00"},
+		{name: "cron ranges", expression: "*-*-1-5 12:00"},
+		{name: "step from star", expression: "*/15:00"},
+		{name: "fractional seconds", expression: "12:00:00.5"},
+		{name: "hour 24", expression: "24:00"},
+		{name: "month 13", expression: "*-13-01"},
+		{name: "zero days from the end", expression: "*-*~0"},
+		{name: "29 days from the end", expression: "*-*~29"},
+		{name: "mixed days from the start and end", expression: "*-*-01,~03"},
+		{name: "unknown time zone", expression: "12:00 Mars/Olympus_Mons"},
+		{name: "time before date", expression: "12:00 *-*-01"},
+		{name: "too many fields", expression: "Mon *-*-* 12:00 UTC UTC"},
+		{name: "shorthand with a date", expression: "daily *-*-01"},
+		{name: "year before 1970", expression: "1969-01-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseOnCalendar(tt.expression)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCron_OnCalendar(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if !assert.NoError(t, err) {
+		return
+	}
+	tests := []struct {
+		name     string
+		schedule string
+		opts     []Option
+		want     string
+		wantErr  bool
+	}{
+		{name: "every minute", schedule: "* * * * *", want: "*-*-* *:*:00 UTC"},
+		{name: "weekdays", schedule: "0 9 * * 1-5", want: "Mon..Fri *-*-* 09:00:00 UTC"},
+		{name: "weekend", schedule: "0 9 * * 0,6", want: "Sat..Sun *-*-* 09:00:00 UTC"},
+		{name: "steps", schedule: "*/15 */2 */5 */3 *", want: "*-01/3-01/5 00/2:00/15:00 UTC"},
+		{name: "lists and ranges", schedule: "0,30 9-17 1,15 * *", want: "*-*-01,15 09..17:00/30:00 UTC"},
+		{name: "last days", schedule: "0 0 L,L-2 * *", want: "*-*~01,03 00:00:00 UTC"},
+		{name: "every other last day", schedule: "0 0 L,L-2,L-4,L-6 * *", want: "*-*~01,03,05,07 00:00:00 UTC"},
+		{name: "last four weeks", schedule: "0 0 L-27,L-26,L-25,L-24,L-23,L-22,L-21,L-20,L-19,L-18,L-17,L-16,L-15,L-14,L-13,L-12,L-11,L-10,L-9,L-8,L-7,L-6,L-5,L-4,L-3,L-2,L-1,L * *", want: "*-*~01..28 00:00:00 UTC"},
+		{name: "too far from the end", schedule: "0 0 L-28 * *", wantErr: true},
+		{name: "years", schedule: "0 0 1 1 * 2024-2026", opts: []Option{WithYears()}, want: "2024..2026-01-01 00:00:00 UTC"},
+		{name: "seconds", schedule: "30 0 9 * * *", opts: []Option{WithSeconds()}, want: "*-*-* 09:00:30 UTC"},
+		{name: "location", schedule: "0 9 * * *", opts: []Option{WithLocation(berlin)}, want: "*-*-* 09:00:00 Europe/Berlin"},
+		{name: "local", schedule: "0 9 * * *", opts: []Option{WithLocation(time.Local)}, want: "*-*-* 09:00:00"},
+		{name: "nearest weekday", schedule: "0 9 15W * *", wantErr: true},
+		{name: "nth weekday", schedule: "0 9 * * 5#3", wantErr: true},
+		{name: "either day field", schedule: "0 9 1 * 1", opts: []Option{WithDayMatch(DayMatchOr)}, wantErr: true},
+		{name: "not before", schedule: "0 9 * * *", opts: []Option{WithNotBefore(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))}, wantErr: true},
+		{name: "not after", schedule: "0 9 * * *", opts: []Option{WithNotAfter(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := Parse(tt.schedule, tt.opts...)
+			if !assert.NoError(t, err) {
+				return
+			}
+			got, err := cron.OnCalendar()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, UnrepresentableSchedule), "want UnrepresentableSchedule, got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// The calendar event activates at the same times as the schedule
+			event, err := ParseOnCalendar(got, WithLocation(cron.loc))
+			if assert.NoError(t, err, got) {
+				from := time.Date(2023, 6, 17, 18, 23, 0, 0, time.UTC)
+				for i := 0; i < 5; i++ {
+					want := cron.NextFrom(from)
+					from = event.NextFrom(from)
+					assert.True(t, want.Equal(from), "want %s, got %s", want, from)
+				}
+			}
+		})
+	}
+}